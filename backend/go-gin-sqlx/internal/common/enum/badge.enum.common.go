@@ -0,0 +1,83 @@
+package enum
+
+type BadgeEnum string
+
+const (
+	BADGE_STUDENT       BadgeEnum = "student"
+	BADGE_TEACHER       BadgeEnum = "teacher"
+	BADGE_SCHOLAR       BadgeEnum = "scholar"
+	BADGE_COMMENTATOR   BadgeEnum = "commentator"
+	BADGE_SUPPORTER     BadgeEnum = "supporter"
+	BADGE_CRITIC        BadgeEnum = "critic"
+	BADGE_NICE_QUESTION BadgeEnum = "nice_question"
+	BADGE_NICE_ANSWER   BadgeEnum = "nice_answer"
+	BADGE_GOOD_ANSWER   BadgeEnum = "good_answer"
+	BADGE_GREAT_ANSWER  BadgeEnum = "great_answer"
+)
+
+func (e BadgeEnum) ToString() string {
+	switch e {
+	case BADGE_STUDENT:
+		return "student"
+	case BADGE_TEACHER:
+		return "teacher"
+	case BADGE_SCHOLAR:
+		return "scholar"
+	case BADGE_COMMENTATOR:
+		return "commentator"
+	case BADGE_SUPPORTER:
+		return "supporter"
+	case BADGE_CRITIC:
+		return "critic"
+	case BADGE_NICE_QUESTION:
+		return "nice_question"
+	case BADGE_NICE_ANSWER:
+		return "nice_answer"
+	case BADGE_GOOD_ANSWER:
+		return "good_answer"
+	case BADGE_GREAT_ANSWER:
+		return "great_answer"
+	default:
+		return ""
+	}
+}
+
+func (e BadgeEnum) IsValid() bool {
+	switch e {
+	case BADGE_STUDENT, BADGE_TEACHER, BADGE_SCHOLAR, BADGE_COMMENTATOR, BADGE_SUPPORTER, BADGE_CRITIC,
+		BADGE_NICE_QUESTION, BADGE_NICE_ANSWER, BADGE_GOOD_ANSWER, BADGE_GREAT_ANSWER:
+		return true
+	}
+
+	return false
+}
+
+type BadgeTierEnum string
+
+const (
+	BADGE_TIER_BRONZE BadgeTierEnum = "bronze"
+	BADGE_TIER_SILVER BadgeTierEnum = "silver"
+	BADGE_TIER_GOLD   BadgeTierEnum = "gold"
+)
+
+func (e BadgeTierEnum) ToString() string {
+	switch e {
+	case BADGE_TIER_BRONZE:
+		return "bronze"
+	case BADGE_TIER_SILVER:
+		return "silver"
+	case BADGE_TIER_GOLD:
+		return "gold"
+	default:
+		return ""
+	}
+}
+
+func (e BadgeTierEnum) IsValid() bool {
+	switch e {
+	case BADGE_TIER_BRONZE, BADGE_TIER_SILVER, BADGE_TIER_GOLD:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,31 @@
+package enum
+
+type BountyStatusEnum string
+
+const (
+	BOUNTY_ACTIVE   BountyStatusEnum = "active"
+	BOUNTY_AWARDED  BountyStatusEnum = "awarded"
+	BOUNTY_REFUNDED BountyStatusEnum = "refunded"
+)
+
+func (e BountyStatusEnum) ToString() string {
+	switch e {
+	case BOUNTY_ACTIVE:
+		return "active"
+	case BOUNTY_AWARDED:
+		return "awarded"
+	case BOUNTY_REFUNDED:
+		return "refunded"
+	default:
+		return ""
+	}
+}
+
+func (e BountyStatusEnum) IsValid() bool {
+	switch e {
+	case BOUNTY_ACTIVE, BOUNTY_AWARDED, BOUNTY_REFUNDED:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+package enum
+
+type CloseReasonEnum string
+
+const (
+	CLOSE_REASON_DUPLICATE CloseReasonEnum = "duplicate"
+	CLOSE_REASON_OFF_TOPIC CloseReasonEnum = "off_topic"
+	CLOSE_REASON_UNCLEAR   CloseReasonEnum = "unclear"
+)
+
+func (e CloseReasonEnum) ToString() string {
+	switch e {
+	case CLOSE_REASON_DUPLICATE:
+		return "duplicate"
+	case CLOSE_REASON_OFF_TOPIC:
+		return "off_topic"
+	case CLOSE_REASON_UNCLEAR:
+		return "unclear"
+	default:
+		return ""
+	}
+}
+
+func (e CloseReasonEnum) IsValid() bool {
+	switch e {
+	case CLOSE_REASON_DUPLICATE, CLOSE_REASON_OFF_TOPIC, CLOSE_REASON_UNCLEAR:
+		return true
+	}
+
+	return false
+}
+
+type CloseVoteActionEnum string
+
+const (
+	CLOSE_VOTE_CLOSE  CloseVoteActionEnum = "close"
+	CLOSE_VOTE_REOPEN CloseVoteActionEnum = "reopen"
+)
+
+func (e CloseVoteActionEnum) ToString() string {
+	switch e {
+	case CLOSE_VOTE_CLOSE:
+		return "close"
+	case CLOSE_VOTE_REOPEN:
+		return "reopen"
+	default:
+		return ""
+	}
+}
+
+func (e CloseVoteActionEnum) IsValid() bool {
+	switch e {
+	case CLOSE_VOTE_CLOSE, CLOSE_VOTE_REOPEN:
+		return true
+	}
+
+	return false
+}
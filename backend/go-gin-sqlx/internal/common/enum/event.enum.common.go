@@ -0,0 +1,39 @@
+package enum
+
+// DomainEventEnum is a change to the site content that other features react to
+type DomainEventEnum string
+
+const (
+	DOMAIN_EVENT_QUESTION_CREATED DomainEventEnum = "question_created"
+	DOMAIN_EVENT_ANSWER_CREATED   DomainEventEnum = "answer_created"
+	DOMAIN_EVENT_ANSWER_ACCEPTED  DomainEventEnum = "answer_accepted"
+	DOMAIN_EVENT_COMMENT_CREATED  DomainEventEnum = "comment_created"
+	DOMAIN_EVENT_VOTE_CAST        DomainEventEnum = "vote_cast"
+)
+
+func (e DomainEventEnum) ToString() string {
+	switch e {
+	case DOMAIN_EVENT_QUESTION_CREATED:
+		return "question_created"
+	case DOMAIN_EVENT_ANSWER_CREATED:
+		return "answer_created"
+	case DOMAIN_EVENT_ANSWER_ACCEPTED:
+		return "answer_accepted"
+	case DOMAIN_EVENT_COMMENT_CREATED:
+		return "comment_created"
+	case DOMAIN_EVENT_VOTE_CAST:
+		return "vote_cast"
+	default:
+		return ""
+	}
+}
+
+func (e DomainEventEnum) IsValid() bool {
+	switch e {
+	case DOMAIN_EVENT_QUESTION_CREATED, DOMAIN_EVENT_ANSWER_CREATED, DOMAIN_EVENT_ANSWER_ACCEPTED,
+		DOMAIN_EVENT_COMMENT_CREATED, DOMAIN_EVENT_VOTE_CAST:
+		return true
+	}
+
+	return false
+}
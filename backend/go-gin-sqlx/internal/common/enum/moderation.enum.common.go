@@ -0,0 +1,91 @@
+package enum
+
+type FlagReasonEnum string
+
+const (
+	FLAG_REASON_SPAM              FlagReasonEnum = "spam"
+	FLAG_REASON_RUDE              FlagReasonEnum = "rude"
+	FLAG_REASON_NEEDS_IMPROVEMENT FlagReasonEnum = "needs_improvement"
+)
+
+func (e FlagReasonEnum) ToString() string {
+	switch e {
+	case FLAG_REASON_SPAM:
+		return "spam"
+	case FLAG_REASON_RUDE:
+		return "rude"
+	case FLAG_REASON_NEEDS_IMPROVEMENT:
+		return "needs_improvement"
+	default:
+		return ""
+	}
+}
+
+func (e FlagReasonEnum) IsValid() bool {
+	switch e {
+	case FLAG_REASON_SPAM, FLAG_REASON_RUDE, FLAG_REASON_NEEDS_IMPROVEMENT:
+		return true
+	}
+
+	return false
+}
+
+type FlagStatusEnum string
+
+const (
+	FLAG_PENDING   FlagStatusEnum = "pending"
+	FLAG_APPROVED  FlagStatusEnum = "approved"
+	FLAG_DISMISSED FlagStatusEnum = "dismissed"
+)
+
+func (e FlagStatusEnum) ToString() string {
+	switch e {
+	case FLAG_PENDING:
+		return "pending"
+	case FLAG_APPROVED:
+		return "approved"
+	case FLAG_DISMISSED:
+		return "dismissed"
+	default:
+		return ""
+	}
+}
+
+func (e FlagStatusEnum) IsValid() bool {
+	switch e {
+	case FLAG_PENDING, FLAG_APPROVED, FLAG_DISMISSED:
+		return true
+	}
+
+	return false
+}
+
+type ModerationActionEnum string
+
+const (
+	MODERATION_APPROVE   ModerationActionEnum = "approve"
+	MODERATION_DISMISS   ModerationActionEnum = "dismiss"
+	MODERATION_AUTO_HIDE ModerationActionEnum = "auto_hide"
+)
+
+func (e ModerationActionEnum) ToString() string {
+	switch e {
+	case MODERATION_APPROVE:
+		return "approve"
+	case MODERATION_DISMISS:
+		return "dismiss"
+	case MODERATION_AUTO_HIDE:
+		return "auto_hide"
+	default:
+		return ""
+	}
+}
+
+func (e ModerationActionEnum) IsValid() bool {
+	switch e {
+	case MODERATION_APPROVE, MODERATION_DISMISS, MODERATION_AUTO_HIDE:
+		return true
+	}
+
+	return false
+}
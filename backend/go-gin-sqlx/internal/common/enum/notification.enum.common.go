@@ -0,0 +1,73 @@
+package enum
+
+// NotificationTypeEnum is why a recipient got the notification
+type NotificationTypeEnum string
+
+const (
+	NOTIFICATION_ANSWER            NotificationTypeEnum = "answer"
+	NOTIFICATION_COMMENT           NotificationTypeEnum = "comment"
+	NOTIFICATION_MENTION           NotificationTypeEnum = "mention"
+	NOTIFICATION_FOLLOWED_QUESTION NotificationTypeEnum = "followed_question"
+)
+
+func (e NotificationTypeEnum) ToString() string {
+	switch e {
+	case NOTIFICATION_ANSWER:
+		return "answer"
+	case NOTIFICATION_COMMENT:
+		return "comment"
+	case NOTIFICATION_MENTION:
+		return "mention"
+	case NOTIFICATION_FOLLOWED_QUESTION:
+		return "followed_question"
+	default:
+		return ""
+	}
+}
+
+func (e NotificationTypeEnum) IsValid() bool {
+	switch e {
+	case NOTIFICATION_ANSWER, NOTIFICATION_COMMENT, NOTIFICATION_MENTION, NOTIFICATION_FOLLOWED_QUESTION:
+		return true
+	}
+
+	return false
+}
+
+// NotificationEventEnum is what happened on the question
+type NotificationEventEnum string
+
+const (
+	NOTIFICATION_EVENT_ANSWER_CREATED    NotificationEventEnum = "answer_created"
+	NOTIFICATION_EVENT_COMMENT_CREATED   NotificationEventEnum = "comment_created"
+	NOTIFICATION_EVENT_QUESTION_UPDATED  NotificationEventEnum = "question_updated"
+	NOTIFICATION_EVENT_QUESTION_CLOSED   NotificationEventEnum = "question_closed"
+	NOTIFICATION_EVENT_QUESTION_REOPENED NotificationEventEnum = "question_reopened"
+)
+
+func (e NotificationEventEnum) ToString() string {
+	switch e {
+	case NOTIFICATION_EVENT_ANSWER_CREATED:
+		return "answer_created"
+	case NOTIFICATION_EVENT_COMMENT_CREATED:
+		return "comment_created"
+	case NOTIFICATION_EVENT_QUESTION_UPDATED:
+		return "question_updated"
+	case NOTIFICATION_EVENT_QUESTION_CLOSED:
+		return "question_closed"
+	case NOTIFICATION_EVENT_QUESTION_REOPENED:
+		return "question_reopened"
+	default:
+		return ""
+	}
+}
+
+func (e NotificationEventEnum) IsValid() bool {
+	switch e {
+	case NOTIFICATION_EVENT_ANSWER_CREATED, NOTIFICATION_EVENT_COMMENT_CREATED, NOTIFICATION_EVENT_QUESTION_UPDATED,
+		NOTIFICATION_EVENT_QUESTION_CLOSED, NOTIFICATION_EVENT_QUESTION_REOPENED:
+		return true
+	}
+
+	return false
+}
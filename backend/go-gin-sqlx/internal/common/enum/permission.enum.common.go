@@ -0,0 +1,43 @@
+package enum
+
+// PermissionEnum is granted to users through their roles, the codes are
+// mirrored in su_permissions
+type PermissionEnum string
+
+const (
+	PERMISSION_QUESTIONS_MODERATE PermissionEnum = "questions:moderate"
+	PERMISSION_QUESTIONS_CLOSE    PermissionEnum = "questions:close"
+	PERMISSION_POSTS_EDIT         PermissionEnum = "posts:edit"
+	PERMISSION_ROLES_MANAGE       PermissionEnum = "roles:manage"
+	PERMISSION_TAGS_MANAGE        PermissionEnum = "tags:manage"
+	PERMISSION_QUERIES_EXECUTE    PermissionEnum = "queries:execute"
+)
+
+func (e PermissionEnum) ToString() string {
+	switch e {
+	case PERMISSION_QUESTIONS_MODERATE:
+		return "questions:moderate"
+	case PERMISSION_QUESTIONS_CLOSE:
+		return "questions:close"
+	case PERMISSION_POSTS_EDIT:
+		return "posts:edit"
+	case PERMISSION_ROLES_MANAGE:
+		return "roles:manage"
+	case PERMISSION_TAGS_MANAGE:
+		return "tags:manage"
+	case PERMISSION_QUERIES_EXECUTE:
+		return "queries:execute"
+	default:
+		return ""
+	}
+}
+
+func (e PermissionEnum) IsValid() bool {
+	switch e {
+	case PERMISSION_QUESTIONS_MODERATE, PERMISSION_QUESTIONS_CLOSE, PERMISSION_POSTS_EDIT, PERMISSION_ROLES_MANAGE,
+		PERMISSION_TAGS_MANAGE, PERMISSION_QUERIES_EXECUTE:
+		return true
+	}
+
+	return false
+}
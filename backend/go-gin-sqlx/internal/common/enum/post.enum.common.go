@@ -0,0 +1,31 @@
+package enum
+
+type PostTypeEnum string
+
+const (
+	POST_TYPE_QUESTION PostTypeEnum = "question"
+	POST_TYPE_ANSWER   PostTypeEnum = "answer"
+	POST_TYPE_COMMENT  PostTypeEnum = "comment"
+)
+
+func (e PostTypeEnum) ToString() string {
+	switch e {
+	case POST_TYPE_QUESTION:
+		return "question"
+	case POST_TYPE_ANSWER:
+		return "answer"
+	case POST_TYPE_COMMENT:
+		return "comment"
+	default:
+		return ""
+	}
+}
+
+func (e PostTypeEnum) IsValid() bool {
+	switch e {
+	case POST_TYPE_QUESTION, POST_TYPE_ANSWER, POST_TYPE_COMMENT:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,31 @@
+package enum
+
+type QuestionStatusEnum string
+
+const (
+	QUESTION_OPEN     QuestionStatusEnum = "open"
+	QUESTION_ANSWERED QuestionStatusEnum = "answered"
+	QUESTION_CLOSED   QuestionStatusEnum = "closed"
+)
+
+func (e QuestionStatusEnum) ToString() string {
+	switch e {
+	case QUESTION_OPEN:
+		return "open"
+	case QUESTION_ANSWERED:
+		return "answered"
+	case QUESTION_CLOSED:
+		return "closed"
+	default:
+		return ""
+	}
+}
+
+func (e QuestionStatusEnum) IsValid() bool {
+	switch e {
+	case QUESTION_OPEN, QUESTION_ANSWERED, QUESTION_CLOSED:
+		return true
+	}
+
+	return false
+}
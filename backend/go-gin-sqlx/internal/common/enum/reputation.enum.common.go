@@ -0,0 +1,88 @@
+package enum
+
+type ReputationReasonEnum string
+
+const (
+	REPUTATION_UPVOTE_RECEIVED   ReputationReasonEnum = "upvote_received"
+	REPUTATION_DOWNVOTE_RECEIVED ReputationReasonEnum = "downvote_received"
+	REPUTATION_DOWNVOTE_CAST     ReputationReasonEnum = "downvote_cast"
+	REPUTATION_ANSWER_ACCEPTED   ReputationReasonEnum = "answer_accepted"
+	REPUTATION_ACCEPT_BONUS      ReputationReasonEnum = "accept_bonus"
+	REPUTATION_BOUNTY_OFFERED    ReputationReasonEnum = "bounty_offered"
+	REPUTATION_BOUNTY_AWARDED    ReputationReasonEnum = "bounty_awarded"
+	REPUTATION_BOUNTY_REFUNDED   ReputationReasonEnum = "bounty_refunded"
+)
+
+func (e ReputationReasonEnum) ToString() string {
+	switch e {
+	case REPUTATION_UPVOTE_RECEIVED:
+		return "upvote_received"
+	case REPUTATION_DOWNVOTE_RECEIVED:
+		return "downvote_received"
+	case REPUTATION_DOWNVOTE_CAST:
+		return "downvote_cast"
+	case REPUTATION_ANSWER_ACCEPTED:
+		return "answer_accepted"
+	case REPUTATION_ACCEPT_BONUS:
+		return "accept_bonus"
+	case REPUTATION_BOUNTY_OFFERED:
+		return "bounty_offered"
+	case REPUTATION_BOUNTY_AWARDED:
+		return "bounty_awarded"
+	case REPUTATION_BOUNTY_REFUNDED:
+		return "bounty_refunded"
+	default:
+		return ""
+	}
+}
+
+func (e ReputationReasonEnum) IsValid() bool {
+	switch e {
+	case REPUTATION_UPVOTE_RECEIVED, REPUTATION_DOWNVOTE_RECEIVED, REPUTATION_DOWNVOTE_CAST,
+		REPUTATION_ANSWER_ACCEPTED, REPUTATION_ACCEPT_BONUS,
+		REPUTATION_BOUNTY_OFFERED, REPUTATION_BOUNTY_AWARDED, REPUTATION_BOUNTY_REFUNDED:
+		return true
+	}
+
+	return false
+}
+
+type PrivilegeEnum string
+
+const (
+	PRIVILEGE_DOWN_VOTE      PrivilegeEnum = "down_vote"
+	PRIVILEGE_EDIT_OTHERS    PrivilegeEnum = "edit_others"
+	PRIVILEGE_CLOSE_QUESTION PrivilegeEnum = "close_question"
+	PRIVILEGE_MODERATE       PrivilegeEnum = "moderate"
+	PRIVILEGE_MANAGE_TAGS    PrivilegeEnum = "manage_tags"
+	PRIVILEGE_FLAG           PrivilegeEnum = "flag"
+)
+
+func (e PrivilegeEnum) ToString() string {
+	switch e {
+	case PRIVILEGE_DOWN_VOTE:
+		return "down_vote"
+	case PRIVILEGE_EDIT_OTHERS:
+		return "edit_others"
+	case PRIVILEGE_CLOSE_QUESTION:
+		return "close_question"
+	case PRIVILEGE_MODERATE:
+		return "moderate"
+	case PRIVILEGE_MANAGE_TAGS:
+		return "manage_tags"
+	case PRIVILEGE_FLAG:
+		return "flag"
+	default:
+		return ""
+	}
+}
+
+func (e PrivilegeEnum) IsValid() bool {
+	switch e {
+	case PRIVILEGE_DOWN_VOTE, PRIVILEGE_EDIT_OTHERS, PRIVILEGE_CLOSE_QUESTION, PRIVILEGE_MODERATE,
+		PRIVILEGE_MANAGE_TAGS, PRIVILEGE_FLAG:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,39 @@
+package enum
+
+// QuestionEventEnum names the live updates pushed on a question stream
+type QuestionEventEnum string
+
+const (
+	QUESTION_EVENT_ANSWER_CREATED  QuestionEventEnum = "answer_created"
+	QUESTION_EVENT_ANSWER_ACCEPTED QuestionEventEnum = "answer_accepted"
+	QUESTION_EVENT_COMMENT_CREATED QuestionEventEnum = "comment_created"
+	QUESTION_EVENT_SCORE_CHANGED   QuestionEventEnum = "score_changed"
+	QUESTION_EVENT_STATUS_CHANGED  QuestionEventEnum = "status_changed"
+)
+
+func (e QuestionEventEnum) ToString() string {
+	switch e {
+	case QUESTION_EVENT_ANSWER_CREATED:
+		return "answer_created"
+	case QUESTION_EVENT_ANSWER_ACCEPTED:
+		return "answer_accepted"
+	case QUESTION_EVENT_COMMENT_CREATED:
+		return "comment_created"
+	case QUESTION_EVENT_SCORE_CHANGED:
+		return "score_changed"
+	case QUESTION_EVENT_STATUS_CHANGED:
+		return "status_changed"
+	default:
+		return ""
+	}
+}
+
+func (e QuestionEventEnum) IsValid() bool {
+	switch e {
+	case QUESTION_EVENT_ANSWER_CREATED, QUESTION_EVENT_ANSWER_ACCEPTED, QUESTION_EVENT_COMMENT_CREATED,
+		QUESTION_EVENT_SCORE_CHANGED, QUESTION_EVENT_STATUS_CHANGED:
+		return true
+	}
+
+	return false
+}
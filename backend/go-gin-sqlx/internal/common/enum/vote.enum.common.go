@@ -0,0 +1,67 @@
+package enum
+
+type VoteTargetEnum string
+
+const (
+	VOTE_TARGET_QUESTION VoteTargetEnum = "question"
+	VOTE_TARGET_ANSWER   VoteTargetEnum = "answer"
+)
+
+func (e VoteTargetEnum) ToString() string {
+	switch e {
+	case VOTE_TARGET_QUESTION:
+		return "question"
+	case VOTE_TARGET_ANSWER:
+		return "answer"
+	default:
+		return ""
+	}
+}
+
+func (e VoteTargetEnum) IsValid() bool {
+	switch e {
+	case VOTE_TARGET_QUESTION, VOTE_TARGET_ANSWER:
+		return true
+	}
+
+	return false
+}
+
+type VoteDirectionEnum string
+
+const (
+	VOTE_UP   VoteDirectionEnum = "up"
+	VOTE_DOWN VoteDirectionEnum = "down"
+)
+
+func (e VoteDirectionEnum) ToString() string {
+	switch e {
+	case VOTE_UP:
+		return "up"
+	case VOTE_DOWN:
+		return "down"
+	default:
+		return ""
+	}
+}
+
+func (e VoteDirectionEnum) IsValid() bool {
+	switch e {
+	case VOTE_UP, VOTE_DOWN:
+		return true
+	}
+
+	return false
+}
+
+// Value returns the score delta of the direction: +1 for up, -1 for down
+func (e VoteDirectionEnum) Value() int {
+	switch e {
+	case VOTE_UP:
+		return 1
+	case VOTE_DOWN:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,9 @@
+package dto
+
+type CreateAnswerRequest struct {
+	Content string `json:"content" binding:"required,min=10,max=10000"`
+}
+
+type UpdateAnswerRequest struct {
+	Content string `json:"content" binding:"required,min=10,max=10000"`
+}
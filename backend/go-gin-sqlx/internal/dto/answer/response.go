@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/markdown"
+
+	"github.com/google/uuid"
+)
+
+type AnswerResponse struct {
+	ID           uuid.UUID `json:"id"`
+	QuestionID   uuid.UUID `json:"question_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	Username     string    `json:"username"`
+	Content      string    `json:"content"`
+	BodyMarkdown string    `json:"body_markdown"`
+	BodyHTML     string    `json:"body_html"`
+	IsAccepted   bool      `json:"is_accepted"`
+	Score        int       `json:"score"`
+	CreatedAt    string    `json:"created_at"`
+	UpdatedAt    string    `json:"updated_at"`
+}
+
+func NewAnswerResponse(a entity.Answer) AnswerResponse {
+	return AnswerResponse{
+		ID:           a.ID,
+		QuestionID:   a.QuestionID,
+		UserID:       a.UserID,
+		Username:     a.Username,
+		Content:      a.Content,
+		BodyMarkdown: a.Content,
+		BodyHTML:     markdown.Render(a.Content),
+		IsAccepted:   a.IsAccepted,
+		Score:        a.Score,
+		CreatedAt:    a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    a.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewAnswerResponses(answers []entity.Answer) []AnswerResponse {
+	responses := make([]AnswerResponse, 0, len(answers))
+	for _, a := range answers {
+		responses = append(responses, NewAnswerResponse(a))
+	}
+	return responses
+}
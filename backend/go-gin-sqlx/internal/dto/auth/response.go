@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type UserResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+}
+
+// AuthResponse is the user shape the clients expect plus the issued tokens
+type AuthResponse struct {
+	UserResponse
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresAt    string `json:"expires_at"`
+}
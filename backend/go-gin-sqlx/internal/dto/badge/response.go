@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// BadgeResponse is one entry of the badge catalog
+type BadgeResponse struct {
+	Badge       enum.BadgeEnum     `json:"badge"`
+	Name        string             `json:"name"`
+	Tier        enum.BadgeTierEnum `json:"tier"`
+	Description string             `json:"description"`
+	Awarded     int                `json:"awarded"`
+}
+
+type UserBadgeResponse struct {
+	Badge     enum.BadgeEnum     `json:"badge"`
+	Name      string             `json:"name"`
+	Tier      enum.BadgeTierEnum `json:"tier"`
+	PostType  *enum.PostTypeEnum `json:"post_type"`
+	PostID    *uuid.UUID         `json:"post_id"`
+	AwardedAt string             `json:"awarded_at"`
+}
+
+// BackfillResponse counts the badges a backfill run awarded that were missing
+type BackfillResponse struct {
+	Awarded int                    `json:"awarded"`
+	ByBadge map[enum.BadgeEnum]int `json:"by_badge"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/google/uuid"
+
+// StartBountyRequest runs the bounty for DurationDays, 7 when omitted
+type StartBountyRequest struct {
+	Amount       int `json:"amount" binding:"required,min=50,max=500"`
+	DurationDays int `json:"duration_days" binding:"omitempty,min=1,max=7"`
+}
+
+type AwardBountyRequest struct {
+	AnswerID uuid.UUID `json:"answer_id" binding:"required"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type BountyResponse struct {
+	ID         uuid.UUID             `json:"id"`
+	QuestionID uuid.UUID             `json:"question_id"`
+	UserID     uuid.UUID             `json:"user_id"`
+	Amount     int                   `json:"amount"`
+	Status     enum.BountyStatusEnum `json:"status"`
+	AnswerID   *uuid.UUID            `json:"answer_id"`
+	ExpiresAt  string                `json:"expires_at"`
+	ResolvedAt *string               `json:"resolved_at"`
+	CreatedAt  string                `json:"created_at"`
+}
+
+func NewBountyResponse(b entity.Bounty) BountyResponse {
+	var resolvedAt *string
+	if b.ResolvedAt != nil {
+		formatted := b.ResolvedAt.Format(time.RFC3339)
+		resolvedAt = &formatted
+	}
+
+	return BountyResponse{
+		ID:         b.ID,
+		QuestionID: b.QuestionID,
+		UserID:     b.UserID,
+		Amount:     b.Amount,
+		Status:     b.Status,
+		AnswerID:   b.AnswerID,
+		ExpiresAt:  b.ExpiresAt.Format(time.RFC3339),
+		ResolvedAt: resolvedAt,
+		CreatedAt:  b.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewBountyResponses(bounties []entity.Bounty) []BountyResponse {
+	responses := make([]BountyResponse, 0, len(bounties))
+	for _, b := range bounties {
+		responses = append(responses, NewBountyResponse(b))
+	}
+	return responses
+}
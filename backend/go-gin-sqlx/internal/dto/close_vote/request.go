@@ -0,0 +1,12 @@
+package dto
+
+import (
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+type CloseQuestionRequest struct {
+	Reason      enum.CloseReasonEnum `json:"reason" binding:"required,oneof=duplicate off_topic unclear"`
+	DuplicateOf *uuid.UUID           `json:"duplicate_of" binding:"required_if=Reason duplicate"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	questionDto "api-stack-underflow/internal/dto/question"
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type CloseVoteResponse struct {
+	ID          uuid.UUID                `json:"id"`
+	UserID      uuid.UUID                `json:"user_id"`
+	Username    string                   `json:"username"`
+	Action      enum.CloseVoteActionEnum `json:"action"`
+	Reason      *enum.CloseReasonEnum    `json:"reason"`
+	DuplicateOf *uuid.UUID               `json:"duplicate_of"`
+	Resolved    bool                     `json:"resolved"`
+	CreatedAt   string                   `json:"created_at"`
+}
+
+// CloseVoteResultResponse reports the tally after a close or reopen vote
+type CloseVoteResultResponse struct {
+	Question    questionDto.QuestionResponse `json:"question"`
+	Action      enum.CloseVoteActionEnum     `json:"action"`
+	Votes       int                          `json:"votes"`
+	VotesNeeded int                          `json:"votes_needed"`
+	Resolved    bool                         `json:"resolved"`
+}
+
+func NewCloseVoteResponse(v entity.CloseVote) CloseVoteResponse {
+	return CloseVoteResponse{
+		ID:          v.ID,
+		UserID:      v.UserID,
+		Username:    v.Username,
+		Action:      v.Action,
+		Reason:      v.Reason,
+		DuplicateOf: v.DuplicateOf,
+		Resolved:    v.Resolved,
+		CreatedAt:   v.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewCloseVoteResponses(votes []entity.CloseVote) []CloseVoteResponse {
+	responses := make([]CloseVoteResponse, 0, len(votes))
+	for _, v := range votes {
+		responses = append(responses, NewCloseVoteResponse(v))
+	}
+	return responses
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/markdown"
+
+	"github.com/google/uuid"
+)
+
+type CommentResponse struct {
+	ID           uuid.UUID `json:"id"`
+	QuestionID   uuid.UUID `json:"question_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	Username     string    `json:"username"`
+	Content      string    `json:"content"`
+	BodyMarkdown string    `json:"body_markdown"`
+	BodyHTML     string    `json:"body_html"`
+	CreatedAt    string    `json:"created_at"`
+	UpdatedAt    string    `json:"updated_at"`
+}
+
+func NewCommentResponse(c entity.Comment) CommentResponse {
+	return CommentResponse{
+		ID:           c.ID,
+		QuestionID:   c.QuestionID,
+		UserID:       c.UserID,
+		Username:     c.Username,
+		Content:      c.Content,
+		BodyMarkdown: c.Content,
+		BodyHTML:     markdown.Render(c.Content),
+		CreatedAt:    c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    c.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewCommentResponses(comments []entity.Comment) []CommentResponse {
+	responses := make([]CommentResponse, 0, len(comments))
+	for _, c := range comments {
+		responses = append(responses, NewCommentResponse(c))
+	}
+	return responses
+}
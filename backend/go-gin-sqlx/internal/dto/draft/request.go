@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+type DraftKeyRequest struct {
+	Key string `uri:"key" binding:"required,draft_key"`
+}
+
+// SaveDraftRequest allows incomplete posts, only the upper limits of the
+// create requests apply until the draft is submitted
+type SaveDraftRequest struct {
+	Type        enum.PostTypeEnum `json:"type" binding:"required,oneof=question answer"`
+	QuestionID  *uuid.UUID        `json:"question_id" binding:"required_if=Type answer"`
+	Title       string            `json:"title" binding:"max=200"`
+	Description string            `json:"description" binding:"max=5000"`
+	Tags        []string          `json:"tags" binding:"omitempty,max=5,dive,max=35"`
+	Content     string            `json:"content" binding:"max=10000"`
+}
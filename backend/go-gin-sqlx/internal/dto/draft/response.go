@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	answerDto "api-stack-underflow/internal/dto/answer"
+	questionDto "api-stack-underflow/internal/dto/question"
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type DraftResponse struct {
+	Key         string            `json:"key"`
+	Type        enum.PostTypeEnum `json:"type"`
+	QuestionID  *uuid.UUID        `json:"question_id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags"`
+	Content     string            `json:"content"`
+	UpdatedAt   string            `json:"updated_at"`
+	ExpiresAt   string            `json:"expires_at"`
+}
+
+// SubmitDraftResponse holds the post created from the draft, Question or Answer depending on Type
+type SubmitDraftResponse struct {
+	Type     enum.PostTypeEnum             `json:"type"`
+	Question *questionDto.QuestionResponse `json:"question,omitempty"`
+	Answer   *answerDto.AnswerResponse     `json:"answer,omitempty"`
+}
+
+func NewDraftResponse(d entity.Draft) DraftResponse {
+	tags := d.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return DraftResponse{
+		Key:         d.Key,
+		Type:        d.Type,
+		QuestionID:  d.QuestionID,
+		Title:       d.Title,
+		Description: d.Description,
+		Tags:        tags,
+		Content:     d.Content,
+		UpdatedAt:   d.UpdatedAt.Format(time.RFC3339),
+		ExpiresAt:   d.ExpiresAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+type CreateFlagRequest struct {
+	PostType enum.PostTypeEnum   `json:"post_type" binding:"required,oneof=question answer comment"`
+	PostID   uuid.UUID           `json:"post_id" binding:"required"`
+	Reason   enum.FlagReasonEnum `json:"reason" binding:"required,oneof=spam rude needs_improvement"`
+	Note     string              `json:"note" binding:"omitempty,max=500"`
+}
+
+type ReviewFlagRequest struct {
+	Note string `json:"note" binding:"omitempty,max=500"`
+}
@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type FlagResponse struct {
+	ID         uuid.UUID           `json:"id"`
+	PostType   enum.PostTypeEnum   `json:"post_type"`
+	PostID     uuid.UUID           `json:"post_id"`
+	QuestionID uuid.UUID           `json:"question_id"`
+	UserID     uuid.UUID           `json:"user_id"`
+	Username   string              `json:"username"`
+	Reason     enum.FlagReasonEnum `json:"reason"`
+	Note       string              `json:"note"`
+	Status     enum.FlagStatusEnum `json:"status"`
+	ReviewedBy *uuid.UUID          `json:"reviewed_by"`
+	ReviewedAt *string             `json:"reviewed_at"`
+	CreatedAt  string              `json:"created_at"`
+}
+
+// FlagResultResponse reports the flag and whether its post is hidden afterwards
+type FlagResultResponse struct {
+	Flag       FlagResponse `json:"flag"`
+	PostHidden bool         `json:"post_hidden"`
+}
+
+type ModerationLogResponse struct {
+	ID          uuid.UUID                        `json:"id"`
+	Action      enum.ModerationActionEnum        `json:"action"`
+	PostType    enum.PostTypeEnum                `json:"post_type"`
+	PostID      uuid.UUID                        `json:"post_id"`
+	FlagID      *uuid.UUID                       `json:"flag_id"`
+	ModeratorID *uuid.UUID                       `json:"moderator_id"`
+	Notes       string                           `json:"notes"`
+	Changes     map[string]entity.RevisionChange `json:"changes"`
+	CreatedAt   string                           `json:"created_at"`
+}
+
+func NewFlagResponse(f entity.Flag) FlagResponse {
+	return FlagResponse{
+		ID:         f.ID,
+		PostType:   f.PostType,
+		PostID:     f.PostID,
+		QuestionID: f.QuestionID,
+		UserID:     f.UserID,
+		Username:   f.Username,
+		Reason:     f.Reason,
+		Note:       f.Note,
+		Status:     f.Status,
+		ReviewedBy: f.ReviewedBy,
+		ReviewedAt: formatOptionalTime(f.ReviewedAt),
+		CreatedAt:  f.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewFlagResponses(flags []entity.Flag) []FlagResponse {
+	responses := make([]FlagResponse, 0, len(flags))
+	for _, f := range flags {
+		responses = append(responses, NewFlagResponse(f))
+	}
+	return responses
+}
+
+func NewModerationLogResponse(l entity.ModerationLog) ModerationLogResponse {
+	// A payload that fails to decode is still listed, just without its diff
+	payload, _ := l.DecodePayload()
+
+	return ModerationLogResponse{
+		ID:          l.ID,
+		Action:      l.Action,
+		PostType:    l.PostType,
+		PostID:      l.PostID,
+		FlagID:      l.FlagID,
+		ModeratorID: l.ModeratorID,
+		Notes:       payload.Notes,
+		Changes:     payload.Changes,
+		CreatedAt:   l.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewModerationLogResponses(logs []entity.ModerationLog) []ModerationLogResponse {
+	responses := make([]ModerationLogResponse, 0, len(logs))
+	for _, l := range logs {
+		responses = append(responses, NewModerationLogResponse(l))
+	}
+	return responses
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type NotificationResponse struct {
+	ID            uuid.UUID                  `json:"id"`
+	Type          enum.NotificationTypeEnum  `json:"type"`
+	Event         enum.NotificationEventEnum `json:"event"`
+	QuestionID    uuid.UUID                  `json:"question_id"`
+	PostType      enum.PostTypeEnum          `json:"post_type"`
+	PostID        uuid.UUID                  `json:"post_id"`
+	ActorID       uuid.UUID                  `json:"actor_id"`
+	ActorUsername string                     `json:"actor_username"`
+	IsRead        bool                       `json:"is_read"`
+	ReadAt        *string                    `json:"read_at"`
+	CreatedAt     string                     `json:"created_at"`
+}
+
+// UnreadCountResponse holds the unread total and its split per notification type
+type UnreadCountResponse struct {
+	Total  int                               `json:"total"`
+	ByType map[enum.NotificationTypeEnum]int `json:"by_type"`
+}
+
+type MarkReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+type FollowResponse struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	Following  bool      `json:"following"`
+}
+
+func NewNotificationResponse(n entity.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:            n.ID,
+		Type:          n.Type,
+		Event:         n.Event,
+		QuestionID:    n.QuestionID,
+		PostType:      n.PostType,
+		PostID:        n.PostID,
+		ActorID:       n.ActorID,
+		ActorUsername: n.ActorUsername,
+		IsRead:        n.IsRead,
+		ReadAt:        formatOptionalTime(n.ReadAt),
+		CreatedAt:     n.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewNotificationResponses(notifications []entity.Notification) []NotificationResponse {
+	responses := make([]NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		responses = append(responses, NewNotificationResponse(n))
+	}
+	return responses
+}
+
+func NewUnreadCountResponse(counts []entity.UnreadCount) UnreadCountResponse {
+	response := UnreadCountResponse{ByType: make(map[enum.NotificationTypeEnum]int, len(counts))}
+	for _, c := range counts {
+		response.ByType[c.Type] = c.Count
+		response.Total += c.Count
+	}
+	return response
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "api-stack-underflow/internal/common/enum"
+
+type CreateQuestionRequest struct {
+	Title       string   `json:"title" binding:"required,min=5,max=200"`
+	Description string   `json:"description" binding:"required,min=10,max=5000"`
+	Tags        []string `json:"tags" binding:"omitempty,max=5,dive,min=1,max=35"`
+}
+
+// UpdateQuestionRequest edits the content. Status may be echoed back unchanged,
+// accepting an answer and the close and reopen actions change the status.
+type UpdateQuestionRequest struct {
+	Title       *string                  `json:"title,omitempty" binding:"omitempty,min=5,max=200"`
+	Description *string                  `json:"description,omitempty" binding:"omitempty,min=10,max=5000"`
+	Status      *enum.QuestionStatusEnum `json:"status,omitempty"`
+	Tags        *[]string                `json:"tags,omitempty" binding:"omitempty,max=5,dive,min=1,max=35"`
+}
+
+type RelatedQuestionRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type HotQuestionRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	commentDto "api-stack-underflow/internal/dto/comment"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/markdown"
+	"api-stack-underflow/internal/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+type QuestionResponse struct {
+	ID           uuid.UUID               `json:"id"`
+	Title        string                  `json:"title"`
+	Description  string                  `json:"description"`
+	BodyMarkdown string                  `json:"body_markdown"`
+	BodyHTML     string                  `json:"body_html"`
+	Status       enum.QuestionStatusEnum `json:"status"`
+	UserID       uuid.UUID               `json:"user_id"`
+	Username     string                  `json:"username"`
+	Score        int                     `json:"score"`
+	Tags         []string                `json:"tags"`
+	ViewCount    int                     `json:"view_count"`
+	BountyAmount int                     `json:"bounty_amount"`
+	CloseReason  *enum.CloseReasonEnum   `json:"close_reason"`
+	DuplicateOf  *uuid.UUID              `json:"duplicate_of"`
+	ClosedAt     *string                 `json:"closed_at"`
+	CreatedAt    string                  `json:"created_at"`
+	UpdatedAt    string                  `json:"updated_at"`
+}
+
+// DuplicateResponse points readers of a duplicate at the original question
+type DuplicateResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+}
+
+// QuestionDetailResponse is the single question view with its comments embedded
+type QuestionDetailResponse struct {
+	QuestionResponse
+	Comments  []commentDto.CommentResponse `json:"comments"`
+	Duplicate *DuplicateResponse           `json:"duplicate,omitempty"`
+}
+
+// QuestionSearchResponse is a search hit with its relevance and highlighted snippet,
+// the snippet is escaped HTML where only the <mark> tags around matches are markup
+type QuestionSearchResponse struct {
+	QuestionResponse
+	Rank     float64 `json:"rank"`
+	Headline string  `json:"headline"`
+}
+
+func NewQuestionResponse(q entity.Question) QuestionResponse {
+	return QuestionResponse{
+		ID:           q.ID,
+		Title:        q.Title,
+		Description:  q.Description,
+		BodyMarkdown: q.Description,
+		BodyHTML:     markdown.Render(q.Description),
+		Status:       q.Status,
+		UserID:       q.UserID,
+		Username:     q.Username,
+		Score:        q.Score,
+		Tags:         tagNames(q.Tags),
+		ViewCount:    q.ViewCount,
+		BountyAmount: q.BountyAmount,
+		CloseReason:  q.CloseReason,
+		DuplicateOf:  q.DuplicateOf,
+		ClosedAt:     formatOptionalTime(q.ClosedAt),
+		CreatedAt:    q.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    q.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+func tagNames(tags entity.TagNames) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func NewQuestionResponses(questions []entity.Question) []QuestionResponse {
+	responses := make([]QuestionResponse, 0, len(questions))
+	for _, q := range questions {
+		responses = append(responses, NewQuestionResponse(q))
+	}
+	return responses
+}
+
+func NewQuestionSearchResponses(results []entity.QuestionSearchResult) []QuestionSearchResponse {
+	responses := make([]QuestionSearchResponse, 0, len(results))
+	for _, r := range results {
+		responses = append(responses, QuestionSearchResponse{
+			QuestionResponse: NewQuestionResponse(r.Question),
+			Rank:             r.Rank,
+			Headline:         pagination.SanitizeHeadline(r.Headline),
+		})
+	}
+	return responses
+}
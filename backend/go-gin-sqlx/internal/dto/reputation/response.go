@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type ReputationEventResponse struct {
+	ID        uuid.UUID                 `json:"id"`
+	Amount    int                       `json:"amount"`
+	Reason    enum.ReputationReasonEnum `json:"reason"`
+	PostType  enum.VoteTargetEnum       `json:"post_type"`
+	PostID    uuid.UUID                 `json:"post_id"`
+	CreatedAt string                    `json:"created_at"`
+}
+
+// ReputationHistoryResponse is the audit view of a user's ledger
+type ReputationHistoryResponse struct {
+	UserID     uuid.UUID                 `json:"user_id"`
+	Username   string                    `json:"username"`
+	Reputation int                       `json:"reputation"`
+	Events     []ReputationEventResponse `json:"events"`
+	Total      int                       `json:"total"`
+	Page       int                       `json:"page"`
+	PageSize   int                       `json:"page_size"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+func NewReputationEventResponse(e entity.ReputationEvent) ReputationEventResponse {
+	return ReputationEventResponse{
+		ID:        e.ID,
+		Amount:    e.Amount,
+		Reason:    e.Reason,
+		PostType:  e.PostType,
+		PostID:    e.PostID,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewReputationEventResponses(events []entity.ReputationEvent) []ReputationEventResponse {
+	responses := make([]ReputationEventResponse, 0, len(events))
+	for _, e := range events {
+		responses = append(responses, NewReputationEventResponse(e))
+	}
+	return responses
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type RevisionResponse struct {
+	ID         uuid.UUID                        `json:"id"`
+	QuestionID uuid.UUID                        `json:"question_id"`
+	PostType   enum.PostTypeEnum                `json:"post_type"`
+	PostID     uuid.UUID                        `json:"post_id"`
+	UserID     *uuid.UUID                       `json:"user_id"`
+	Username   string                           `json:"username"`
+	Notes      string                           `json:"notes"`
+	Changes    map[string]entity.RevisionChange `json:"changes"`
+	CreatedAt  string                           `json:"created_at"`
+}
+
+func NewRevisionResponse(r entity.Revision) RevisionResponse {
+	// A payload that fails to decode is still listed, just without its diff
+	payload, _ := r.DecodePayload()
+
+	return RevisionResponse{
+		ID:         r.ID,
+		QuestionID: r.QuestionID,
+		PostType:   r.PostType,
+		PostID:     r.PostID,
+		UserID:     r.UserID,
+		Username:   r.Username,
+		Notes:      payload.Notes,
+		Changes:    payload.Changes,
+		CreatedAt:  r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewRevisionResponses(revisions []entity.Revision) []RevisionResponse {
+	responses := make([]RevisionResponse, 0, len(revisions))
+	for _, r := range revisions {
+		responses = append(responses, NewRevisionResponse(r))
+	}
+	return responses
+}
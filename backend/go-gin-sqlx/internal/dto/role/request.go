@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// CreateRoleRequest creates an active role unless IsActive is false
+type CreateRoleRequest struct {
+	Code        string                `json:"code" binding:"required,min=1,max=50"`
+	Name        string                `json:"name" binding:"required,min=1,max=255"`
+	Description string                `json:"description" binding:"required,min=1"`
+	IsActive    *bool                 `json:"is_active"`
+	Permissions []enum.PermissionEnum `json:"permissions" binding:"omitempty,dive,required"`
+}
+
+// UpdateRoleRequest changes the fields that are set, Permissions replaces the whole set
+type UpdateRoleRequest struct {
+	Code        string                 `json:"code" binding:"omitempty,min=1,max=50"`
+	Name        string                 `json:"name" binding:"omitempty,min=1,max=255"`
+	Description string                 `json:"description" binding:"omitempty,min=1"`
+	IsActive    *bool                  `json:"is_active"`
+	Permissions *[]enum.PermissionEnum `json:"permissions" binding:"omitempty,dive,required"`
+}
+
+type AssignRoleRequest struct {
+	RoleID uuid.UUID `json:"role_id" binding:"required"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type RoleResponse struct {
+	ID          uuid.UUID             `json:"id"`
+	Code        string                `json:"code"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	IsActive    bool                  `json:"is_active"`
+	Permissions []enum.PermissionEnum `json:"permissions"`
+	CreatedAt   string                `json:"created_at"`
+	UpdatedAt   string                `json:"updated_at"`
+}
+
+// UserPermissionsResponse lists what the active roles of a user grant
+type UserPermissionsResponse struct {
+	UserID      uuid.UUID             `json:"user_id"`
+	Roles       []RoleResponse        `json:"roles"`
+	Permissions []enum.PermissionEnum `json:"permissions"`
+}
+
+func NewRoleResponse(r entity.Role) RoleResponse {
+	return RoleResponse{
+		ID:          r.ID,
+		Code:        r.Code,
+		Name:        r.Name,
+		Description: r.Description,
+		IsActive:    r.IsActive,
+		Permissions: r.Permissions,
+		CreatedAt:   r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   r.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewRoleResponses(roles []entity.Role) []RoleResponse {
+	responses := make([]RoleResponse, 0, len(roles))
+	for _, r := range roles {
+		responses = append(responses, NewRoleResponse(r))
+	}
+	return responses
+}
@@ -0,0 +1,14 @@
+package dto
+
+type CreateTagRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=35"`
+	Description string `json:"description" binding:"omitempty,max=1000"`
+}
+
+type UpdateTagRequest struct {
+	Description string `json:"description" binding:"required,max=1000"`
+}
+
+type CreateSynonymRequest struct {
+	Synonym string `json:"synonym" binding:"required,min=1,max=35"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+type TagResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	UsageCount  int       `json:"usage_count"`
+	CreatedAt   string    `json:"created_at"`
+}
+
+// TagDetailResponse backs the tag page
+type TagDetailResponse struct {
+	TagResponse
+	Synonyms []string `json:"synonyms"`
+}
+
+func NewTagResponse(t entity.Tag) TagResponse {
+	return TagResponse{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		UsageCount:  t.UsageCount,
+		CreatedAt:   t.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewTagResponses(tags []entity.Tag) []TagResponse {
+	responses := make([]TagResponse, 0, len(tags))
+	for _, t := range tags {
+		responses = append(responses, NewTagResponse(t))
+	}
+	return responses
+}
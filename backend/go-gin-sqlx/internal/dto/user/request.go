@@ -0,0 +1,8 @@
+package dto
+
+// UpdateProfileRequest changes only the fields that are sent, an empty avatar_url removes the avatar
+type UpdateProfileRequest struct {
+	Username  *string `json:"username,omitempty" binding:"omitempty,username"`
+	Bio       *string `json:"bio,omitempty" binding:"omitempty,max=1000"`
+	AvatarURL *string `json:"avatar_url,omitempty" binding:"omitempty,max=500,len=0|http_url"`
+}
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// excerptLength is how many characters of a post body the activity feed shows
+const excerptLength = 200
+
+type UserProfileResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Username   string    `json:"username"`
+	Bio        string    `json:"bio"`
+	AvatarURL  *string   `json:"avatar_url"`
+	Reputation int       `json:"reputation"`
+	JoinedAt   string    `json:"joined_at"`
+}
+
+// UpdateProfileResponse carries a new access token after a rename, the old
+// one still holds the previous username
+type UpdateProfileResponse struct {
+	UserProfileResponse
+	AccessToken string `json:"access_token,omitempty"`
+	TokenType   string `json:"token_type,omitempty"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+}
+
+type ActivityResponse struct {
+	PostType      enum.PostTypeEnum `json:"post_type"`
+	PostID        uuid.UUID         `json:"post_id"`
+	QuestionID    uuid.UUID         `json:"question_id"`
+	QuestionTitle string            `json:"question_title"`
+	Excerpt       string            `json:"excerpt"`
+	Score         int               `json:"score"`
+	CreatedAt     string            `json:"created_at"`
+}
+
+func NewUserProfileResponse(u entity.User) UserProfileResponse {
+	return UserProfileResponse{
+		ID:         u.ID,
+		Username:   u.Username,
+		Bio:        u.Bio,
+		AvatarURL:  u.AvatarURL,
+		Reputation: u.Reputation,
+		JoinedAt:   u.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewActivityResponse(a entity.Activity) ActivityResponse {
+	return ActivityResponse{
+		PostType:      a.PostType,
+		PostID:        a.PostID,
+		QuestionID:    a.QuestionID,
+		QuestionTitle: a.QuestionTitle,
+		Excerpt:       excerpt(a.Body),
+		Score:         a.Score,
+		CreatedAt:     a.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func NewActivityResponses(activities []entity.Activity) []ActivityResponse {
+	responses := make([]ActivityResponse, 0, len(activities))
+	for _, a := range activities {
+		responses = append(responses, NewActivityResponse(a))
+	}
+	return responses
+}
+
+func excerpt(body string) string {
+	if utf8.RuneCountInString(body) <= excerptLength {
+		return body
+	}
+	return string([]rune(body)[:excerptLength]) + "…"
+}
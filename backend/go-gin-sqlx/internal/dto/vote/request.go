@@ -0,0 +1,7 @@
+package dto
+
+import "api-stack-underflow/internal/common/enum"
+
+type VoteRequest struct {
+	Direction enum.VoteDirectionEnum `json:"direction" binding:"required,oneof=up down"`
+}
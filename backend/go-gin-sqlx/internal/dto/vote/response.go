@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+type VoteResponse struct {
+	TargetType enum.VoteTargetEnum `json:"target_type"`
+	TargetID   uuid.UUID           `json:"target_id"`
+	Score      int                 `json:"score"`
+	UserVote   int                 `json:"user_vote"`
+}
+
+// ScoreResponse is the public part of a vote, broadcast to everyone viewing the question
+type ScoreResponse struct {
+	TargetType enum.VoteTargetEnum `json:"target_type"`
+	TargetID   uuid.UUID           `json:"target_id"`
+	Score      int                 `json:"score"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Answer represents a row of the su_answers table
+type Answer struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	QuestionID uuid.UUID `db:"question_id" json:"question_id"`
+	UserID     uuid.UUID `db:"user_id" json:"user_id"`
+	Username   string    `db:"username" json:"username"`
+	Content    string    `db:"content" json:"content"`
+	IsAccepted bool      `db:"is_accepted" json:"is_accepted"`
+	Score      int       `db:"score" json:"score"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// UserBadge represents a row of the su_user_badges table. PostID is set for
+// badges earned once per post and nil for badges earned once per user.
+type UserBadge struct {
+	ID        uuid.UUID      `db:"id" json:"id"`
+	UserID    uuid.UUID      `db:"user_id" json:"user_id"`
+	Badge     enum.BadgeEnum `db:"badge" json:"badge"`
+	PostID    *uuid.UUID     `db:"post_id" json:"post_id"`
+	AwardedAt time.Time      `db:"awarded_at" json:"awarded_at"`
+}
+
+// BadgeCount is how many times a badge has been awarded
+type BadgeCount struct {
+	Badge enum.BadgeEnum `db:"badge" json:"badge"`
+	Count int            `db:"count" json:"count"`
+}
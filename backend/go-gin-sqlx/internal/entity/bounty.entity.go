@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// Bounty represents a row of the su_bounties table. AnswerID is set once the
+// bounty is awarded, ResolvedAt once it is awarded or refunded.
+type Bounty struct {
+	ID         uuid.UUID             `db:"id" json:"id"`
+	QuestionID uuid.UUID             `db:"question_id" json:"question_id"`
+	UserID     uuid.UUID             `db:"user_id" json:"user_id"`
+	Amount     int                   `db:"amount" json:"amount"`
+	Status     enum.BountyStatusEnum `db:"status" json:"status"`
+	AnswerID   *uuid.UUID            `db:"answer_id" json:"answer_id"`
+	ExpiresAt  time.Time             `db:"expires_at" json:"expires_at"`
+	ResolvedAt *time.Time            `db:"resolved_at" json:"resolved_at"`
+	CreatedAt  time.Time             `db:"created_at" json:"created_at"`
+}
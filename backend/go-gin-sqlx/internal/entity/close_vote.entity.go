@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// CloseVote represents a row of the su_close_votes table. Rows are kept after
+// the question closes or reopens (Resolved) so they double as the audit trail.
+type CloseVote struct {
+	ID          uuid.UUID                `db:"id" json:"id"`
+	QuestionID  uuid.UUID                `db:"question_id" json:"question_id"`
+	UserID      uuid.UUID                `db:"user_id" json:"user_id"`
+	Username    string                   `db:"username" json:"username"`
+	Action      enum.CloseVoteActionEnum `db:"action" json:"action"`
+	Reason      *enum.CloseReasonEnum    `db:"reason" json:"reason"`
+	DuplicateOf *uuid.UUID               `db:"duplicate_of" json:"duplicate_of"`
+	Resolved    bool                     `db:"resolved" json:"resolved"`
+	CreatedAt   time.Time                `db:"created_at" json:"created_at"`
+}
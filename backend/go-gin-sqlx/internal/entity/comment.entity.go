@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment represents a row of the su_comments table
+type Comment struct {
+	ID         uuid.UUID `db:"id" json:"id"`
+	QuestionID uuid.UUID `db:"question_id" json:"question_id"`
+	UserID     uuid.UUID `db:"user_id" json:"user_id"`
+	Username   string    `db:"username" json:"username"`
+	Content    string    `db:"content" json:"content"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// Draft is an unsubmitted question or answer autosaved in Redis. Question
+// drafts use Title, Description and Tags, answer drafts QuestionID and Content.
+type Draft struct {
+	Key         string            `json:"key"`
+	Type        enum.PostTypeEnum `json:"type"`
+	QuestionID  *uuid.UUID        `json:"question_id,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Content     string            `json:"content,omitempty"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// DomainEvent is published after a content change is committed. ActorID made
+// the change, OwnerID is the author of the post it happened to when that is
+// someone else (the voted or accepted post).
+type DomainEvent struct {
+	ID        uuid.UUID            `json:"id"`
+	Event     enum.DomainEventEnum `json:"event"`
+	ActorID   uuid.UUID            `json:"actor_id"`
+	OwnerID   *uuid.UUID           `json:"owner_id,omitempty"`
+	PostType  enum.PostTypeEnum    `json:"post_type"`
+	PostID    uuid.UUID            `json:"post_id"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// UserIDs returns the users whose standing the event may have changed
+func (e DomainEvent) UserIDs() []uuid.UUID {
+	if e.OwnerID == nil || *e.OwnerID == e.ActorID {
+		return []uuid.UUID{e.ActorID}
+	}
+	return []uuid.UUID{e.ActorID, *e.OwnerID}
+}
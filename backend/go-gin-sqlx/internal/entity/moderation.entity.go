@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// Flag represents a row of the su_flags table
+type Flag struct {
+	ID         uuid.UUID           `db:"id" json:"id"`
+	PostType   enum.PostTypeEnum   `db:"post_type" json:"post_type"`
+	PostID     uuid.UUID           `db:"post_id" json:"post_id"`
+	QuestionID uuid.UUID           `db:"question_id" json:"question_id"`
+	UserID     uuid.UUID           `db:"user_id" json:"user_id"`
+	Username   string              `db:"username" json:"username"`
+	Reason     enum.FlagReasonEnum `db:"reason" json:"reason"`
+	Note       string              `db:"note" json:"note"`
+	Status     enum.FlagStatusEnum `db:"status" json:"status"`
+	ReviewedBy *uuid.UUID          `db:"reviewed_by" json:"reviewed_by"`
+	ReviewedAt *time.Time          `db:"reviewed_at" json:"reviewed_at"`
+	CreatedAt  time.Time           `db:"created_at" json:"created_at"`
+}
+
+// ModeratedPost is the moderation view of a question, answer or comment
+type ModeratedPost struct {
+	Type       enum.PostTypeEnum `db:"-" json:"-"`
+	ID         uuid.UUID         `db:"id" json:"-"`
+	QuestionID uuid.UUID         `db:"question_id" json:"-"`
+	UserID     uuid.UUID         `db:"user_id" json:"-"`
+	IsHidden   bool              `db:"is_hidden" json:"is_hidden"`
+}
+
+// ModerationLog represents a row of the su_moderation_logs table. ModeratorID
+// is nil for automatic actions, Payload is helper.BuildHistoryPayload output.
+type ModerationLog struct {
+	ID          uuid.UUID                 `db:"id" json:"id"`
+	Action      enum.ModerationActionEnum `db:"action" json:"action"`
+	PostType    enum.PostTypeEnum         `db:"post_type" json:"post_type"`
+	PostID      uuid.UUID                 `db:"post_id" json:"post_id"`
+	FlagID      *uuid.UUID                `db:"flag_id" json:"flag_id"`
+	ModeratorID *uuid.UUID                `db:"moderator_id" json:"moderator_id"`
+	Payload     []byte                    `db:"payload" json:"payload"`
+	CreatedAt   time.Time                 `db:"created_at" json:"created_at"`
+}
+
+// DecodePayload decodes the log payload, it has the same shape as a revision payload
+func (l ModerationLog) DecodePayload() (RevisionPayload, error) {
+	var payload RevisionPayload
+	if err := json.Unmarshal(l.Payload, &payload); err != nil {
+		return RevisionPayload{}, err
+	}
+	return payload, nil
+}
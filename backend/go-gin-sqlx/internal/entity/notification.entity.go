@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// Notification represents a row of the su_notifications table. EventID ties
+// the rows fanned out from one NotificationEvent so a redelivery is a no-op.
+type Notification struct {
+	ID            uuid.UUID                  `db:"id" json:"id"`
+	UserID        uuid.UUID                  `db:"user_id" json:"user_id"`
+	EventID       uuid.UUID                  `db:"event_id" json:"event_id"`
+	Type          enum.NotificationTypeEnum  `db:"type" json:"type"`
+	Event         enum.NotificationEventEnum `db:"event" json:"event"`
+	QuestionID    uuid.UUID                  `db:"question_id" json:"question_id"`
+	PostType      enum.PostTypeEnum          `db:"post_type" json:"post_type"`
+	PostID        uuid.UUID                  `db:"post_id" json:"post_id"`
+	ActorID       uuid.UUID                  `db:"actor_id" json:"actor_id"`
+	ActorUsername string                     `db:"actor_username" json:"actor_username"`
+	IsRead        bool                       `db:"is_read" json:"is_read"`
+	ReadAt        *time.Time                 `db:"read_at" json:"read_at"`
+	CreatedAt     time.Time                  `db:"created_at" json:"created_at"`
+}
+
+// NotificationEvent is the message published to the notification queue, the
+// consumer turns it into one Notification per recipient
+type NotificationEvent struct {
+	ID            uuid.UUID                  `json:"id"`
+	Event         enum.NotificationEventEnum `json:"event"`
+	QuestionID    uuid.UUID                  `json:"question_id"`
+	PostType      enum.PostTypeEnum          `json:"post_type"`
+	PostID        uuid.UUID                  `json:"post_id"`
+	ActorID       uuid.UUID                  `json:"actor_id"`
+	ActorUsername string                     `json:"actor_username"`
+	Mentions      []string                   `json:"mentions,omitempty"`
+	CreatedAt     time.Time                  `json:"created_at"`
+}
+
+// UnreadCount is the number of unread notifications of one type
+type UnreadCount struct {
+	Type  enum.NotificationTypeEnum `db:"type" json:"type"`
+	Count int                       `db:"count" json:"count"`
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// Question represents a row of the su_questions table. BountyAmount is the
+// active bounty, 0 without one.
+type Question struct {
+	ID           uuid.UUID               `db:"id" json:"id"`
+	Title        string                  `db:"title" json:"title"`
+	Description  string                  `db:"description" json:"description"`
+	Status       enum.QuestionStatusEnum `db:"status" json:"status"`
+	UserID       uuid.UUID               `db:"user_id" json:"user_id"`
+	Username     string                  `db:"username" json:"username"`
+	Score        int                     `db:"score" json:"score"`
+	Tags         TagNames                `db:"tags" json:"tags"`
+	ViewCount    int                     `db:"view_count" json:"view_count"`
+	BountyAmount int                     `db:"bounty_amount" json:"bounty_amount"`
+	CloseReason  *enum.CloseReasonEnum   `db:"close_reason" json:"close_reason"`
+	DuplicateOf  *uuid.UUID              `db:"duplicate_of" json:"duplicate_of"`
+	ClosedAt     *time.Time              `db:"closed_at" json:"closed_at"`
+	IsHidden     bool                    `db:"is_hidden" json:"is_hidden"`
+	CreatedAt    time.Time               `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time               `db:"updated_at" json:"updated_at"`
+}
+
+// QuestionSearchResult is a question matched by full-text search
+type QuestionSearchResult struct {
+	Question
+	Rank     float64 `db:"rank" json:"rank"`
+	Headline string  `db:"headline" json:"headline"`
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// ReputationEvent represents a row of the su_reputation_events ledger.
+// Reversals (retracted votes, unaccepted answers) are stored as negated rows.
+type ReputationEvent struct {
+	ID        uuid.UUID                 `db:"id" json:"id"`
+	UserID    uuid.UUID                 `db:"user_id" json:"user_id"`
+	Amount    int                       `db:"amount" json:"amount"`
+	Reason    enum.ReputationReasonEnum `db:"reason" json:"reason"`
+	PostType  enum.VoteTargetEnum       `db:"post_type" json:"post_type"`
+	PostID    uuid.UUID                 `db:"post_id" json:"post_id"`
+	ActorID   *uuid.UUID                `db:"actor_id" json:"actor_id"`
+	CreatedAt time.Time                 `db:"created_at" json:"created_at"`
+}
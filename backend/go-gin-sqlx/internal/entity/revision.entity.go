@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// Revision represents a row of the su_revisions table. Payload holds the
+// output of helper.BuildHistoryPayload for one edit of a post.
+type Revision struct {
+	ID         uuid.UUID         `db:"id" json:"id"`
+	QuestionID uuid.UUID         `db:"question_id" json:"question_id"`
+	PostType   enum.PostTypeEnum `db:"post_type" json:"post_type"`
+	PostID     uuid.UUID         `db:"post_id" json:"post_id"`
+	UserID     *uuid.UUID        `db:"user_id" json:"user_id"`
+	Username   string            `db:"username" json:"username"`
+	Payload    []byte            `db:"payload" json:"payload"`
+	CreatedAt  time.Time         `db:"created_at" json:"created_at"`
+}
+
+// RevisionPayload is the decoded form of Revision.Payload
+type RevisionPayload struct {
+	Notes   string                    `json:"notes"`
+	Changes map[string]RevisionChange `json:"changes"`
+}
+
+// RevisionChange keeps the raw JSON of a field so it can be written back unchanged
+type RevisionChange struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+func (r Revision) DecodePayload() (RevisionPayload, error) {
+	var payload RevisionPayload
+	if err := json.Unmarshal(r.Payload, &payload); err != nil {
+		return RevisionPayload{}, err
+	}
+	return payload, nil
+}
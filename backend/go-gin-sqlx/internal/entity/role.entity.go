@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// Role represents a row of the su_roles table with the permissions it grants
+type Role struct {
+	ID          uuid.UUID             `db:"id" json:"id"`
+	Code        string                `db:"code" json:"code"`
+	Name        string                `db:"name" json:"name"`
+	Description string                `db:"description" json:"description"`
+	IsActive    bool                  `db:"is_active" json:"is_active"`
+	Permissions []enum.PermissionEnum `db:"-" json:"permissions"`
+	CreatedAt   time.Time             `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time             `db:"updated_at" json:"updated_at"`
+}
+
+// RolePermission represents a row of the su_role_permissions table
+type RolePermission struct {
+	RoleID     uuid.UUID           `db:"role_id" json:"role_id"`
+	Permission enum.PermissionEnum `db:"permission_code" json:"permission"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// QuestionEvent is a live update of a question, relayed between API instances
+// through Redis pub/sub. Data is the JSON response of whatever changed.
+type QuestionEvent struct {
+	ID         uuid.UUID              `json:"id"`
+	Type       enum.QuestionEventEnum `json:"type"`
+	QuestionID uuid.UUID              `json:"question_id"`
+	Data       json.RawMessage        `json:"data"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag represents a row of the su_tags table
+type Tag struct {
+	ID          uuid.UUID  `db:"id" json:"id"`
+	Name        string     `db:"name" json:"name"`
+	Description string     `db:"description" json:"description"`
+	CreatedBy   *uuid.UUID `db:"created_by" json:"created_by"`
+	UsageCount  int        `db:"usage_count" json:"usage_count"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// TagSynonym maps an alternative spelling to its canonical tag
+type TagSynonym struct {
+	Name      string    `db:"name" json:"name"`
+	TagID     uuid.UUID `db:"tag_id" json:"tag_id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// TagNames is a Postgres text[] column holding tag names
+type TagNames []string
+
+// Scan parses the Postgres array literal, e.g. {go,sql}
+func (t *TagNames) Scan(src any) error {
+	var raw string
+	switch v := src.(type) {
+	case nil:
+		*t = TagNames{}
+		return nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("unsupported type %T for TagNames", src)
+	}
+
+	raw = strings.TrimSuffix(strings.TrimPrefix(raw, "{"), "}")
+	names := TagNames{}
+	if raw != "" {
+		// Tag names are restricted to [a-z0-9+#.-] so they never need quoting
+		for _, name := range strings.Split(raw, ",") {
+			names = append(names, strings.Trim(name, `"`))
+		}
+	}
+	*t = names
+	return nil
+}
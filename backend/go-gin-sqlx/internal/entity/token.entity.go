@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken represents a row of the su_refresh_tokens table. Every token
+// rotated from the same login shares its FamilyID.
+type RefreshToken struct {
+	JTI        string     `db:"jti" json:"jti"`
+	FamilyID   uuid.UUID  `db:"family_id" json:"family_id"`
+	UserID     uuid.UUID  `db:"user_id" json:"user_id"`
+	ReplacedBy *string    `db:"replaced_by" json:"replaced_by"`
+	UsedAt     *time.Time `db:"used_at" json:"used_at"`
+	RevokedAt  *time.Time `db:"revoked_at" json:"revoked_at"`
+	ExpiresAt  time.Time  `db:"expires_at" json:"expires_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+}
+
+// Revocation is broadcast to every instance when a token is revoked, JTI set,
+// or every token of a user issued up to RevokedBefore is, RevokedBefore set
+type Revocation struct {
+	JTI           string     `json:"jti,omitempty"`
+	UserID        uuid.UUID  `json:"user_id"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	RevokedBefore *time.Time `json:"revoked_before,omitempty"`
+}
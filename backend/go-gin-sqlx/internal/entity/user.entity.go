@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// User represents a row of the su_users table
+type User struct {
+	ID         uuid.UUID  `db:"id" json:"id"`
+	Username   string     `db:"username" json:"username"`
+	Password   string     `db:"password" json:"-"`
+	Bio        string     `db:"bio" json:"bio"`
+	AvatarURL  *string    `db:"avatar_url" json:"avatar_url"`
+	Reputation int        `db:"reputation" json:"reputation"`
+	BannedAt   *time.Time `db:"banned_at" json:"banned_at"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at" json:"updated_at"`
+}
+
+// Activity is a row of the su_user_activity view: one question, answer or
+// comment of a user together with the title of the question it belongs to
+type Activity struct {
+	PostType      enum.PostTypeEnum `db:"post_type" json:"post_type"`
+	PostID        uuid.UUID         `db:"post_id" json:"post_id"`
+	QuestionID    uuid.UUID         `db:"question_id" json:"question_id"`
+	QuestionTitle string            `db:"question_title" json:"question_title"`
+	UserID        uuid.UUID         `db:"user_id" json:"user_id"`
+	Body          string            `db:"body" json:"body"`
+	Score         int               `db:"score" json:"score"`
+	CreatedAt     time.Time         `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "github.com/google/uuid"
+
+// Viewer identifies who opened a question, signed in users by id and
+// anonymous visitors by address
+type Viewer struct {
+	UserID    *uuid.UUID
+	IP        string
+	UserAgent string
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+
+	"github.com/google/uuid"
+)
+
+// Vote represents a row of the su_votes table, one per user and target
+type Vote struct {
+	UserID     uuid.UUID           `db:"user_id" json:"user_id"`
+	TargetType enum.VoteTargetEnum `db:"target_type" json:"target_type"`
+	TargetID   uuid.UUID           `db:"target_id" json:"target_id"`
+	Value      int                 `db:"value" json:"value"`
+	CreatedAt  time.Time           `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time           `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,208 @@
+package answer
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/answer"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	"api-stack-underflow/internal/pkg/pagination"
+	answerService "api-stack-underflow/internal/service/answer"
+	questionService "api-stack-underflow/internal/service/question"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service answerService.IAnswerService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service answerService.IAnswerService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// List godoc
+//
+//	@Summary	List answers of a question
+//	@Tags		Answers
+//	@Produce	json
+//	@Param		id			path		string	true	"Question ID"
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Param		sort_by		query		string	false	"Sort field"
+//	@Param		order		query		string	false	"ASC or DESC"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/answers [get]
+func (h *Handler) List(c *gin.Context) {
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	p, err := pagination.NewPaginationFromQuery(c, answerService.AnswerPaginationConfig(questionID))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), questionID, p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Create godoc
+//
+//	@Summary	Post an answer
+//	@Tags		Answers
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string					true	"Question ID"
+//	@Param		request	body		dto.CreateAnswerRequest	true	"Answer"
+//	@Success	201		{object}	types.ResponseAPI
+//	@Router		/questions/{id}/answers [post]
+func (h *Handler) Create(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Create(c.Request.Context(), user, questionID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Created", result, nil)
+}
+
+// Update godoc
+//
+//	@Summary	Edit an answer
+//	@Tags		Answers
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string					true	"Question ID"
+//	@Param		answerId	path		string					true	"Answer ID"
+//	@Param		request		body		dto.UpdateAnswerRequest	true	"Answer"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/answers/{answerId} [put]
+func (h *Handler) Update(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, answerID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Update(c.Request.Context(), user, questionID, answerID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Delete godoc
+//
+//	@Summary	Delete an answer
+//	@Tags		Answers
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id			path		string	true	"Question ID"
+//	@Param		answerId	path		string	true	"Answer ID"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/answers/{answerId} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, answerID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), user, questionID, answerID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+// Accept godoc
+//
+//	@Summary	Accept an answer
+//	@Tags		Answers
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id			path		string	true	"Question ID"
+//	@Param		answerId	path		string	true	"Answer ID"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/answers/{answerId}/accept [post]
+func (h *Handler) Accept(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, answerID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Accept(c.Request.Context(), user, questionID, answerID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, answerService.ErrAnswerNotFound),
+		errors.Is(err, questionService.ErrQuestionNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, answerService.ErrAnswerForbidden),
+		errors.Is(err, answerService.ErrAcceptForbidden):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	case errors.Is(err, answerService.ErrQuestionClosed):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	answerID, ok := parseID(c, "answerId")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return questionID, answerID, true
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,20 @@
+package answer
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/questions/:id/answers")
+
+	group.GET("", h.List)
+
+	protected := group.Group("", middleware.AuthMiddleware(h.auth))
+	protected.
+		POST("", h.Create).
+		PUT("/:answerId", h.Update).
+		DELETE("/:answerId", h.Delete).
+		POST("/:answerId/accept", h.Accept)
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/auth"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	authService "api-stack-underflow/internal/service/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service authService.IAuthService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service authService.IAuthService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// Login godoc
+//
+//	@Summary	Login
+//	@Tags		Auth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dto.LoginRequest	true	"Credentials"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/auth/login [post]
+func (h *Handler) Login(c *gin.Context) {
+	var req dto.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Login(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Signup godoc
+//
+//	@Summary	Register a new user
+//	@Tags		Auth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dto.SignupRequest	true	"Credentials"
+//	@Success	201		{object}	types.ResponseAPI
+//	@Router		/auth/signup [post]
+func (h *Handler) Signup(c *gin.Context) {
+	var req dto.SignupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Signup(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Created", result, nil)
+}
+
+// RefreshToken godoc
+//
+//	@Summary		Issue a new access token and rotate the refresh token
+//	@Description	The presented refresh token cannot be used again. Replaying it revokes every token of the login.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.RefreshTokenRequest	true	"Refresh token"
+//	@Success		200		{object}	types.ResponseAPI
+//	@Router			/auth/refresh-token [post]
+func (h *Handler) RefreshToken(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.RefreshToken(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Logout godoc
+//
+//	@Summary	Revoke the presented tokens
+//	@Tags		Auth
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dto.LogoutRequest	false	"Refresh token to revoke"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	claims, _ := middleware.CurrentUser(c)
+
+	// The body is optional, clients may only send the Authorization header
+	var req dto.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+			return
+		}
+	}
+
+	if err := h.service.Logout(c.Request.Context(), claims, req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+// LogoutAll godoc
+//
+//	@Summary	Revoke every token of the current user on every device
+//	@Tags		Auth
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/auth/logout-all [post]
+func (h *Handler) LogoutAll(c *gin.Context) {
+	claims, _ := middleware.CurrentUser(c)
+
+	if err := h.service.LogoutAll(c.Request.Context(), claims.UserID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change the password of the current user
+//	@Description	Every token of the user is revoked, including the one presented, sign in again with the new password.
+//	@Tags			Auth
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.ChangePasswordRequest	true	"Current and new password"
+//	@Success		200		{object}	types.ResponseAPI
+//	@Router			/auth/password [post]
+func (h *Handler) ChangePassword(c *gin.Context) {
+	claims, _ := middleware.CurrentUser(c)
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	if err := h.service.ChangePassword(c.Request.Context(), claims.UserID, req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+// Me godoc
+//
+//	@Summary	Current user
+//	@Tags		Auth
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/auth/me [get]
+func (h *Handler) Me(c *gin.Context) {
+	claims, _ := middleware.CurrentUser(c)
+
+	result, err := h.service.Me(c.Request.Context(), claims.UserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// UserInfo godoc
+//
+//	@Summary	Current user (legacy alias of /auth/me)
+//	@Tags		Auth
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/auth/data [get]
+func (h *Handler) UserInfo(c *gin.Context) {
+	h.Me(c)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, authService.ErrInvalidCredentials),
+		errors.Is(err, authService.ErrInvalidRefreshToken),
+		errors.Is(err, authService.ErrRefreshTokenReused):
+		helper.APIResponse(c, http.StatusUnauthorized, err.Error(), nil, err)
+	case errors.Is(err, authService.ErrWrongPassword):
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+	case errors.Is(err, authService.ErrUserBanned):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	case errors.Is(err, authService.ErrUsernameTaken):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	case errors.Is(err, authService.ErrUserNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
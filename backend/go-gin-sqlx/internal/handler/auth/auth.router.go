@@ -11,6 +11,11 @@ func (h *Handler) NewRoutes(e *gin.RouterGroup) {
 
 	group.
 		POST("/login", h.Login).
+		POST("/signup", h.Signup).
 		POST("/refresh-token", h.RefreshToken).
+		POST("/logout", middleware.AuthMiddleware(h.auth), h.Logout).
+		POST("/logout-all", middleware.AuthMiddleware(h.auth), h.LogoutAll).
+		POST("/password", middleware.AuthMiddleware(h.auth), h.ChangePassword).
+		GET("/me", middleware.AuthMiddleware(h.auth), h.Me).
 		GET("/data", middleware.AuthMiddleware(h.auth), h.UserInfo)
 }
@@ -0,0 +1,86 @@
+package badge
+
+import (
+	"errors"
+	"net/http"
+
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	badgeService "api-stack-underflow/internal/service/badge"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service badgeService.IBadgeService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service badgeService.IBadgeService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// Catalog godoc
+//
+//	@Summary	List every badge with how often it was awarded
+//	@Tags		Badges
+//	@Produce	json
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/badges [get]
+func (h *Handler) Catalog(c *gin.Context) {
+	result, err := h.service.Catalog(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// UserBadges godoc
+//
+//	@Summary	Badges earned by a user, newest first
+//	@Tags		Badges
+//	@Produce	json
+//	@Param		username	path		string	true	"Username"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/users/{username}/badges [get]
+func (h *Handler) UserBadges(c *gin.Context) {
+	result, err := h.service.UserBadges(c.Request.Context(), c.Param("username"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Backfill godoc
+//
+//	@Summary		Award badges missing for existing content
+//	@Description	Evaluates every badge rule against all data. Safe to run again, badges already held are never awarded twice. Requires the moderate privilege.
+//	@Tags			Badges
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Success		200	{object}	types.ResponseAPI
+//	@Router			/badges/backfill [post]
+func (h *Handler) Backfill(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	result, err := h.service.Backfill(c.Request.Context(), user)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, badgeService.ErrUserNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, badgeService.ErrBadgeForbidden):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
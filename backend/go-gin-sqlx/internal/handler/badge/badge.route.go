@@ -0,0 +1,16 @@
+package badge
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/badges")
+	group.
+		GET("", h.Catalog).
+		POST("/backfill", middleware.AuthMiddleware(h.auth), h.Backfill)
+
+	e.GET("/users/:username/badges", h.UserBadges)
+}
@@ -0,0 +1,142 @@
+package bounty
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/bounty"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	answerService "api-stack-underflow/internal/service/answer"
+	bountyService "api-stack-underflow/internal/service/bounty"
+	questionService "api-stack-underflow/internal/service/question"
+	reputationService "api-stack-underflow/internal/service/reputation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service bountyService.IBountyService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service bountyService.IBountyService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// List godoc
+//
+//	@Summary	Bounties of a question, newest first
+//	@Tags		Questions
+//	@Produce	json
+//	@Param		id	path		string	true	"Question ID"
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/questions/{id}/bounties [get]
+func (h *Handler) List(c *gin.Context) {
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), questionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Start godoc
+//
+//	@Summary		Start a bounty on a question
+//	@Description	The amount is taken from the sponsor's reputation right away. When the bounty expires unawarded it goes to the best answer posted meanwhile with a score of 2 or more, otherwise it is refunded.
+//	@Tags			Questions
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Question ID"
+//	@Param			request	body		dto.StartBountyRequest	true	"Amount and duration"
+//	@Success		201		{object}	types.ResponseAPI
+//	@Router			/questions/{id}/bounty [post]
+func (h *Handler) Start(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.StartBountyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Start(c.Request.Context(), user, questionID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Created", result, nil)
+}
+
+// Award godoc
+//
+//	@Summary	Award the active bounty to an answer
+//	@Tags		Questions
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string					true	"Question ID"
+//	@Param		request	body		dto.AwardBountyRequest	true	"Answer"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/questions/{id}/bounty/award [post]
+func (h *Handler) Award(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.AwardBountyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Award(c.Request.Context(), user, questionID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, questionService.ErrQuestionNotFound),
+		errors.Is(err, answerService.ErrAnswerNotFound),
+		errors.Is(err, bountyService.ErrNoActiveBounty):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, bountyService.ErrBountyForbidden),
+		errors.Is(err, bountyService.ErrSelfAward),
+		errors.Is(err, reputationService.ErrInsufficientReputation):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	case errors.Is(err, bountyService.ErrQuestionClosed),
+		errors.Is(err, bountyService.ErrBountyActive),
+		errors.Is(err, bountyService.ErrBountyUnavailable):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,18 @@
+package bounty
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/questions/:id")
+
+	group.GET("/bounties", h.List)
+
+	protected := group.Group("", middleware.AuthMiddleware(h.auth))
+	protected.
+		POST("/bounty", h.Start).
+		POST("/bounty/award", h.Award)
+}
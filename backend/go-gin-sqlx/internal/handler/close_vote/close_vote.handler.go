@@ -0,0 +1,142 @@
+package close_vote
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/close_vote"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	"api-stack-underflow/internal/pkg/pagination"
+	closeVoteService "api-stack-underflow/internal/service/close_vote"
+	questionService "api-stack-underflow/internal/service/question"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service closeVoteService.ICloseVoteService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service closeVoteService.ICloseVoteService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// List godoc
+//
+//	@Summary	Close and reopen votes of a question
+//	@Tags		Questions
+//	@Produce	json
+//	@Param		id			path		string	true	"Question ID"
+//	@Param		action		query		string	false	"Action (close, reopen)"
+//	@Param		resolved	query		bool	false	"Only resolved or pending votes"
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Param		order		query		string	false	"ASC or DESC"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/close-votes [get]
+func (h *Handler) List(c *gin.Context) {
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	p, err := pagination.NewPaginationFromQuery(c, closeVoteService.CloseVotePaginationConfig(questionID))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), questionID, p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Close godoc
+//
+//	@Summary	Vote to close a question
+//	@Tags		Questions
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string						true	"Question ID"
+//	@Param		request	body		dto.CloseQuestionRequest	true	"Close reason"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/questions/{id}/close [post]
+func (h *Handler) Close(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CloseQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Close(c.Request.Context(), user, questionID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Reopen godoc
+//
+//	@Summary	Vote to reopen a closed question
+//	@Tags		Questions
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id	path		string	true	"Question ID"
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/questions/{id}/reopen [post]
+func (h *Handler) Reopen(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Reopen(c.Request.Context(), user, questionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, questionService.ErrQuestionNotFound),
+		errors.Is(err, closeVoteService.ErrDuplicateNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, closeVoteService.ErrCloseVoteForbidden):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	case errors.Is(err, closeVoteService.ErrInvalidDuplicate):
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+	case errors.Is(err, closeVoteService.ErrAlreadyClosed),
+		errors.Is(err, closeVoteService.ErrNotClosed),
+		errors.Is(err, closeVoteService.ErrAlreadyVoted):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
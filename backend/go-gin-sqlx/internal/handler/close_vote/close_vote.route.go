@@ -0,0 +1,18 @@
+package close_vote
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/questions/:id")
+
+	group.GET("/close-votes", h.List)
+
+	protected := group.Group("", middleware.AuthMiddleware(h.auth))
+	protected.
+		POST("/close", h.Close).
+		POST("/reopen", h.Reopen)
+}
@@ -0,0 +1,143 @@
+package comment
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/comment"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	commentService "api-stack-underflow/internal/service/comment"
+	questionService "api-stack-underflow/internal/service/question"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service commentService.ICommentService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service commentService.ICommentService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// Create godoc
+//
+//	@Summary	Add comment to question
+//	@Tags		Comments
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string						true	"Question ID"
+//	@Param		request	body		dto.CreateCommentRequest	true	"Comment"
+//	@Success	201		{object}	types.ResponseAPI
+//	@Router		/questions/{id}/comments [post]
+func (h *Handler) Create(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Create(c.Request.Context(), user, questionID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Created", result, nil)
+}
+
+// Update godoc
+//
+//	@Summary	Edit comment
+//	@Tags		Comments
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string						true	"Question ID"
+//	@Param		commentId	path		string						true	"Comment ID"
+//	@Param		request		body		dto.UpdateCommentRequest	true	"Comment"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/comments/{commentId} [put]
+func (h *Handler) Update(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	commentID, ok := parseID(c, "commentId")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Update(c.Request.Context(), user, questionID, commentID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Delete godoc
+//
+//	@Summary	Delete comment
+//	@Tags		Comments
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id			path		string	true	"Question ID"
+//	@Param		commentId	path		string	true	"Comment ID"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/comments/{commentId} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	commentID, ok := parseID(c, "commentId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), user, questionID, commentID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, commentService.ErrCommentNotFound),
+		errors.Is(err, questionService.ErrQuestionNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, commentService.ErrCommentForbidden):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,17 @@
+package comment
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/questions/:id/comments")
+
+	group.
+		Use(middleware.AuthMiddleware(h.auth)).
+		POST("", h.Create).
+		PUT("/:commentId", h.Update).
+		DELETE("/:commentId", h.Delete)
+}
@@ -0,0 +1,159 @@
+package draft
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/draft"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	answerService "api-stack-underflow/internal/service/answer"
+	draftService "api-stack-underflow/internal/service/draft"
+	questionService "api-stack-underflow/internal/service/question"
+	tagService "api-stack-underflow/internal/service/tag"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service draftService.IDraftService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service draftService.IDraftService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// Get godoc
+//
+//	@Summary	Get a draft
+//	@Tags		Drafts
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		key	path		string	true	"Draft key, 1-64 letters, digits, _ or -"
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/drafts/{key} [get]
+func (h *Handler) Get(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	key, ok := bindKey(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Get(c.Request.Context(), user, key)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Save godoc
+//
+//	@Summary		Autosave a draft
+//	@Description	Creates or replaces the draft, which expires 7 days after the last save. Drafts may be incomplete, only the size limits of a post apply.
+//	@Tags			Drafts
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			key		path		string					true	"Draft key, 1-64 letters, digits, _ or -"
+//	@Param			request	body		dto.SaveDraftRequest	true	"Draft"
+//	@Success		200		{object}	types.ResponseAPI
+//	@Router			/drafts/{key} [put]
+func (h *Handler) Save(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	key, ok := bindKey(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SaveDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Save(c.Request.Context(), user, key, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Delete godoc
+//
+//	@Summary	Discard a draft
+//	@Tags		Drafts
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		key	path		string	true	"Draft key"
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/drafts/{key} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	key, ok := bindKey(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), user, key); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Deleted", nil, nil)
+}
+
+// Submit godoc
+//
+//	@Summary		Submit a draft
+//	@Description	Creates the question or answer of the draft and removes the draft. A draft failing validation or creation is kept.
+//	@Tags			Drafts
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			key	path		string	true	"Draft key"
+//	@Success		201	{object}	types.ResponseAPI
+//	@Router			/drafts/{key}/submit [post]
+func (h *Handler) Submit(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	key, ok := bindKey(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Submit(c.Request.Context(), user, key)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Created", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, draftService.ErrDraftNotFound),
+		errors.Is(err, questionService.ErrQuestionNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, draftService.ErrDraftIncomplete),
+		errors.Is(err, tagService.ErrInvalidTagName),
+		errors.Is(err, tagService.ErrTooManyTags):
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+	case errors.Is(err, draftService.ErrDraftTypeImmutable),
+		errors.Is(err, answerService.ErrQuestionClosed):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	case errors.Is(err, draftService.ErrDraftsUnavailable):
+		helper.APIResponse(c, http.StatusServiceUnavailable, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func bindKey(c *gin.Context) (string, bool) {
+	var req dto.DraftKeyRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid key", nil, err)
+		return "", false
+	}
+	return req.Key, true
+}
@@ -0,0 +1,16 @@
+package draft
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	protected := e.Group("/drafts", middleware.AuthMiddleware(h.auth))
+	protected.
+		GET("/:key", h.Get).
+		PUT("/:key", h.Save).
+		DELETE("/:key", h.Delete).
+		POST("/:key/submit", h.Submit)
+}
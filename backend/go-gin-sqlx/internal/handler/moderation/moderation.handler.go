@@ -0,0 +1,201 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/moderation"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	"api-stack-underflow/internal/pkg/pagination"
+	moderationService "api-stack-underflow/internal/service/moderation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service moderationService.IModerationService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service moderationService.IModerationService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// Flag godoc
+//
+//	@Summary	Flag a question, answer or comment
+//	@Tags		Moderation
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dto.CreateFlagRequest	true	"Flag"
+//	@Success	201		{object}	types.ResponseAPI
+//	@Router		/flags [post]
+func (h *Handler) Flag(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	var req dto.CreateFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Flag(c.Request.Context(), user, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Success", result, nil)
+}
+
+// Queue godoc
+//
+//	@Summary	Review queue of flags, pending unless a status is given
+//	@Tags		Moderation
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		status		query		string	false	"Status (pending, approved, dismissed)"
+//	@Param		reason		query		string	false	"Reason (spam, rude, needs_improvement)"
+//	@Param		post_type	query		string	false	"Post type (question, answer, comment)"
+//	@Param		question_id	query		string	false	"Question ID"
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Param		sort_by		query		string	false	"Sort field (id, created_at)"
+//	@Param		order		query		string	false	"ASC or DESC"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/moderation/flags [get]
+func (h *Handler) Queue(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	p, err := pagination.NewPaginationFromQuery(c, moderationService.FlagPaginationConfig())
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Queue(c.Request.Context(), user, p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Approve godoc
+//
+//	@Summary	Approve a flag and hide the post
+//	@Tags		Moderation
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		flagId	path		string					true	"Flag ID"
+//	@Param		request	body		dto.ReviewFlagRequest	false	"Review note"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/moderation/flags/{flagId}/approve [post]
+func (h *Handler) Approve(c *gin.Context) {
+	h.review(c, h.service.Approve)
+}
+
+// Dismiss godoc
+//
+//	@Summary	Dismiss a flag
+//	@Tags		Moderation
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		flagId	path		string					true	"Flag ID"
+//	@Param		request	body		dto.ReviewFlagRequest	false	"Review note"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/moderation/flags/{flagId}/dismiss [post]
+func (h *Handler) Dismiss(c *gin.Context) {
+	h.review(c, h.service.Dismiss)
+}
+
+// Logs godoc
+//
+//	@Summary	Moderation log
+//	@Tags		Moderation
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		action			query		string	false	"Action (approve, dismiss, auto_hide)"
+//	@Param		post_type		query		string	false	"Post type (question, answer, comment)"
+//	@Param		post_id			query		string	false	"Post ID"
+//	@Param		moderator_id	query		string	false	"Moderator ID"
+//	@Param		page			query		int		false	"Page"
+//	@Param		page_size		query		int		false	"Page size"
+//	@Param		order			query		string	false	"ASC or DESC"
+//	@Success	200				{object}	types.ResponseAPI
+//	@Router		/moderation/log [get]
+func (h *Handler) Logs(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	p, err := pagination.NewPaginationFromQuery(c, moderationService.ModerationLogPaginationConfig())
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Logs(c.Request.Context(), user, p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+type reviewFunc func(ctx context.Context, user *jwt.Claims, flagID uuid.UUID, req dto.ReviewFlagRequest) (*dto.FlagResultResponse, error)
+
+func (h *Handler) review(c *gin.Context, action reviewFunc) {
+	user, _ := middleware.CurrentUser(c)
+	flagID, ok := parseID(c, "flagId")
+	if !ok {
+		return
+	}
+
+	// The note is optional, an empty body reviews without one
+	var req dto.ReviewFlagRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+			return
+		}
+	}
+
+	result, err := action(c.Request.Context(), user, flagID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, moderationService.ErrPostNotFound),
+		errors.Is(err, moderationService.ErrFlagNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, moderationService.ErrModerationForbidden),
+		errors.Is(err, moderationService.ErrFlagForbidden):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	case errors.Is(err, moderationService.ErrFlagOwnPost):
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+	case errors.Is(err, moderationService.ErrAlreadyFlagged),
+		errors.Is(err, moderationService.ErrFlagReviewed):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,21 @@
+package moderation
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	e.POST("/flags", middleware.AuthMiddleware(h.auth), h.Flag)
+
+	// Not behind RequirePermission: the moderate privilege is also earned with
+	// reputation, so the service checks it through reputationSvc.Require, which
+	// accepts questions:moderate from a role as well
+	protected := e.Group("/moderation", middleware.AuthMiddleware(h.auth))
+	protected.
+		GET("/flags", h.Queue).
+		POST("/flags/:flagId/approve", h.Approve).
+		POST("/flags/:flagId/dismiss", h.Dismiss).
+		GET("/log", h.Logs)
+}
@@ -0,0 +1,183 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	"api-stack-underflow/internal/pkg/pagination"
+	notificationService "api-stack-underflow/internal/service/notification"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service notificationService.INotificationService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service notificationService.INotificationService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// Follow godoc
+//
+//	@Summary	Follow a question to be notified when it changes
+//	@Tags		Notifications
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id	path		string	true	"Question ID"
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/questions/{id}/follow [post]
+func (h *Handler) Follow(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Follow(c.Request.Context(), user, questionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Unfollow godoc
+//
+//	@Summary	Stop following a question
+//	@Tags		Notifications
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id	path		string	true	"Question ID"
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/questions/{id}/follow [delete]
+func (h *Handler) Unfollow(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Unfollow(c.Request.Context(), user, questionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Inbox godoc
+//
+//	@Summary	Notifications of the current user
+//	@Tags		Notifications
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		is_read		query		bool	false	"Only read or unread notifications"
+//	@Param		type		query		string	false	"Type (answer, comment, mention, followed_question)"
+//	@Param		question_id	query		string	false	"Question ID"
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Param		order		query		string	false	"ASC or DESC"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/notifications [get]
+func (h *Handler) Inbox(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	p, err := pagination.NewPaginationFromQuery(c, notificationService.NotificationPaginationConfig())
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Inbox(c.Request.Context(), user, p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// UnreadCount godoc
+//
+//	@Summary	Unread notification counts, in total and per type
+//	@Tags		Notifications
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/notifications/unread-count [get]
+func (h *Handler) UnreadCount(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	result, err := h.service.UnreadCount(c.Request.Context(), user)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// MarkRead godoc
+//
+//	@Summary	Mark a notification as read
+//	@Tags		Notifications
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		notificationId	path		string	true	"Notification ID"
+//	@Success	200				{object}	types.ResponseAPI
+//	@Router		/notifications/{notificationId}/read [post]
+func (h *Handler) MarkRead(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	id, ok := parseID(c, "notificationId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.MarkRead(c.Request.Context(), user, id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+// MarkAllRead godoc
+//
+//	@Summary	Mark every notification as read
+//	@Tags		Notifications
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/notifications/read-all [post]
+func (h *Handler) MarkAllRead(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	result, err := h.service.MarkAllRead(c.Request.Context(), user)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, notificationService.ErrQuestionNotFound),
+		errors.Is(err, notificationService.ErrNotificationNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
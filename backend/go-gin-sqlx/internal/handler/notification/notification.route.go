@@ -0,0 +1,21 @@
+package notification
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	follow := e.Group("/questions/:id", middleware.AuthMiddleware(h.auth))
+	follow.
+		POST("/follow", h.Follow).
+		DELETE("/follow", h.Unfollow)
+
+	inbox := e.Group("/notifications", middleware.AuthMiddleware(h.auth))
+	inbox.
+		GET("", h.Inbox).
+		GET("/unread-count", h.UnreadCount).
+		POST("/read-all", h.MarkAllRead).
+		POST("/:notificationId/read", h.MarkRead)
+}
@@ -1,18 +1,20 @@
 package query_action_catalog
 
 import (
+	"api-stack-underflow/internal/common/enum"
 	"api-stack-underflow/internal/pkg/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
-func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+// NewRoutes only lets users holding queries:execute run catalog queries, a
+// catalog code can read or write any table
+func (h *Handler) NewRoutes(e *gin.RouterGroup, permissions middleware.PermissionChecker) {
 	group := e.Group("/query")
 
 	group.
-		Use(middleware.AuthMiddleware(h.auth)).
+		Use(middleware.AuthMiddleware(h.auth), middleware.RequirePermission(permissions, enum.PERMISSION_QUERIES_EXECUTE.ToString())).
 		POST(":code", h.ExecutePostQuery).
 		PUT(":code", h.ExecutePutQuery).
 		DELETE(":code", h.ExecuteDeleteQuery)
-
 }
@@ -0,0 +1,274 @@
+package question
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/question"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	"api-stack-underflow/internal/pkg/pagination"
+	questionService "api-stack-underflow/internal/service/question"
+	tagService "api-stack-underflow/internal/service/tag"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service questionService.IQuestionService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service questionService.IQuestionService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// List godoc
+//
+//	@Summary	List questions
+//	@Tags		Questions
+//	@Produce	json
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Param		status		query		string	false	"Status (open, answered, closed)"
+//	@Param		user_id		query		string	false	"Author ID"
+//	@Param		q			query		string	false	"Search title and description"
+//	@Param		tagged		query		string	false	"Comma separated tags, all must match"
+//	@Param		has_bounty	query		bool	false	"Only questions with an active bounty"
+//	@Param		sort_by		query		string	false	"Sort field (id, created_at, score, view_count, bounty_amount)"
+//	@Param		order		query		string	false	"ASC or DESC"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions [get]
+func (h *Handler) List(c *gin.Context) {
+	p, err := pagination.NewPaginationFromQuery(c, questionService.QuestionPaginationConfig())
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Search godoc
+//
+//	@Summary	Full-text search over questions, descriptions and comments
+//	@Tags		Questions
+//	@Produce	json
+//	@Param		q			query		string	true	"Search term, supports "quoted phrases", or and -exclusions"
+//	@Param		status		query		string	false	"Status (open, answered, closed)"
+//	@Param		tagged		query		string	false	"Comma separated tags, all must match"
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/search [get]
+func (h *Handler) Search(c *gin.Context) {
+	p, err := pagination.NewPaginationFromQuery(c, questionService.QuestionPaginationConfig())
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Search(c.Request.Context(), p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Detail godoc
+//
+//	@Summary		Get question detail
+//	@Description	Counts a view once per signed in user, or address when anonymous, every 30 minutes. Bots are not counted.
+//	@Tags			Questions
+//	@Produce		json
+//	@Param			id	path		string	true	"Question ID"
+//	@Success		200	{object}	types.ResponseAPI
+//	@Router			/questions/{id} [get]
+func (h *Handler) Detail(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	viewer := entity.Viewer{IP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	if user, ok := middleware.CurrentUser(c); ok {
+		viewer.UserID = &user.UserID
+	}
+
+	result, err := h.service.Get(c.Request.Context(), id, viewer)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Create godoc
+//
+//	@Summary	Create question
+//	@Tags		Questions
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dto.CreateQuestionRequest	true	"Question"
+//	@Success	201		{object}	types.ResponseAPI
+//	@Router		/questions [post]
+func (h *Handler) Create(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	var req dto.CreateQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Create(c.Request.Context(), user, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Created", result, nil)
+}
+
+// Update godoc
+//
+//	@Summary	Update question
+//	@Tags		Questions
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string						true	"Question ID"
+//	@Param		request	body		dto.UpdateQuestionRequest	true	"Question"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/questions/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Update(c.Request.Context(), user, id, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Delete godoc
+//
+//	@Summary	Delete question
+//	@Tags		Questions
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id	path		string	true	"Question ID"
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/questions/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), user, id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+// Related godoc
+//
+//	@Summary	Related questions
+//	@Tags		Questions
+//	@Produce	json
+//	@Param		id		path		string	true	"Question ID"
+//	@Param		limit	query		int		false	"Max results"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/questions/{id}/related [get]
+func (h *Handler) Related(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.RelatedQuestionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Related(c.Request.Context(), id, req.Limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Hot godoc
+//
+//	@Summary	Hot questions
+//	@Tags		Questions
+//	@Produce	json
+//	@Param		limit	query		int	false	"Max results"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/questions/hot [get]
+func (h *Handler) Hot(c *gin.Context) {
+	var req dto.HotQuestionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Hot(c.Request.Context(), req.Limit)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, questionService.ErrQuestionNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, questionService.ErrQuestionForbidden):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	case errors.Is(err, questionService.ErrEmptySearchQuery),
+		errors.Is(err, tagService.ErrInvalidTagName),
+		errors.Is(err, tagService.ErrTooManyTags):
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+	case errors.Is(err, questionService.ErrStatusWorkflowRequired),
+		errors.Is(err, questionService.ErrActiveBounty):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
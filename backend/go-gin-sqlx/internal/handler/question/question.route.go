@@ -0,0 +1,24 @@
+package question
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/questions")
+
+	group.
+		GET("", h.List).
+		GET("/search", h.Search).
+		GET("/hot", h.Hot).
+		GET("/:id", middleware.OptionalAuthMiddleware(h.auth), h.Detail).
+		GET("/:id/related", h.Related)
+
+	protected := group.Group("", middleware.AuthMiddleware(h.auth))
+	protected.
+		POST("", h.Create).
+		PUT("/:id", h.Update).
+		DELETE("/:id", h.Delete)
+}
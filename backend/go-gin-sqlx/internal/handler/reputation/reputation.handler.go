@@ -0,0 +1,58 @@
+package reputation
+
+import (
+	"errors"
+	"net/http"
+
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/pagination"
+	reputationService "api-stack-underflow/internal/service/reputation"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service reputationService.IReputationService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service reputationService.IReputationService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// History godoc
+//
+//	@Summary	Reputation history of a user
+//	@Tags		Reputation
+//	@Produce	json
+//	@Param		username	path		string	true	"Username"
+//	@Param		reason		query		string	false	"Filter by reason"
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Param		order		query		string	false	"ASC or DESC"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/users/{username}/reputation [get]
+func (h *Handler) History(c *gin.Context) {
+	p, err := pagination.NewPaginationFromQuery(c, reputationService.ReputationPaginationConfig())
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.History(c.Request.Context(), c.Param("username"), p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, reputationService.ErrUserNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
@@ -0,0 +1,11 @@
+package reputation
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/users/:username")
+
+	group.GET("/reputation", h.History)
+}
@@ -0,0 +1,109 @@
+package revision
+
+import (
+	"errors"
+	"net/http"
+
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	"api-stack-underflow/internal/pkg/pagination"
+	revisionService "api-stack-underflow/internal/service/revision"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service revisionService.IRevisionService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service revisionService.IRevisionService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// List godoc
+//
+//	@Summary	List revisions of a question, its answers and comments
+//	@Tags		Revisions
+//	@Produce	json
+//	@Param		id			path		string	true	"Question ID"
+//	@Param		post_type	query		string	false	"Post type (question, answer, comment)"
+//	@Param		post_id		query		string	false	"Post ID"
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Param		order		query		string	false	"ASC or DESC"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/revisions [get]
+func (h *Handler) List(c *gin.Context) {
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	p, err := pagination.NewPaginationFromQuery(c, revisionService.RevisionPaginationConfig(questionID))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), questionID, p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Rollback godoc
+//
+//	@Summary	Restore a post to a revision
+//	@Tags		Revisions
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id			path		string	true	"Question ID"
+//	@Param		revisionId	path		string	true	"Revision ID"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/revisions/{revisionId}/rollback [post]
+func (h *Handler) Rollback(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+	revisionID, ok := parseID(c, "revisionId")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Rollback(c.Request.Context(), user, questionID, revisionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, revisionService.ErrRevisionNotFound),
+		errors.Is(err, revisionService.ErrPostNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, revisionService.ErrRevisionForbidden):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	case errors.Is(err, revisionService.ErrNothingToRollback):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,16 @@
+package revision
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/questions/:id/revisions")
+
+	group.GET("", h.List)
+
+	protected := group.Group("", middleware.AuthMiddleware(h.auth))
+	protected.POST("/:revisionId/rollback", h.Rollback)
+}
@@ -0,0 +1,316 @@
+package role
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/role"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	roleService "api-stack-underflow/internal/service/role"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service roleService.IRoleService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service roleService.IRoleService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// List godoc
+//
+//	@Summary	List roles with their permissions
+//	@Tags		Roles
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/roles [get]
+func (h *Handler) List(c *gin.Context) {
+	result, err := h.service.List(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Detail godoc
+//
+//	@Summary	Get a role
+//	@Tags		Roles
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id	path		string	true	"Role ID"
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/roles/{id} [get]
+func (h *Handler) Detail(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Create godoc
+//
+//	@Summary	Create a role
+//	@Tags		Roles
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dto.CreateRoleRequest	true	"Role"
+//	@Success	201		{object}	types.ResponseAPI
+//	@Router		/roles [post]
+func (h *Handler) Create(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Create(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Created", result, nil)
+}
+
+// Update godoc
+//
+//	@Summary		Update a role
+//	@Description	Only the fields sent are changed, permissions replaces the whole set. Holders of the role see the change on their next request.
+//	@Tags			Roles
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Role ID"
+//	@Param			request	body		dto.UpdateRoleRequest	true	"Role"
+//	@Success		200		{object}	types.ResponseAPI
+//	@Router			/roles/{id} [put]
+func (h *Handler) Update(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Update(c.Request.Context(), id, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Delete godoc
+//
+//	@Summary	Delete a role and every assignment of it
+//	@Tags		Roles
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id	path		string	true	"Role ID"
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/roles/{id} [delete]
+func (h *Handler) Delete(c *gin.Context) {
+	id, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+// Mine godoc
+//
+//	@Summary	Roles and permissions of the current user
+//	@Tags		Roles
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Success	200	{object}	types.ResponseAPI
+//	@Router		/roles/me [get]
+func (h *Handler) Mine(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	result, err := h.service.UserPermissions(c.Request.Context(), user.UserID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// UserRoles godoc
+//
+//	@Summary	Roles and permissions of a user
+//	@Tags		Roles
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		userId	path		string	true	"User ID"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/roles/users/{userId} [get]
+func (h *Handler) UserRoles(c *gin.Context) {
+	userID, ok := parseID(c, "userId")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.UserPermissions(c.Request.Context(), userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Assign godoc
+//
+//	@Summary	Assign a role to a user
+//	@Tags		Roles
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		userId	path		string					true	"User ID"
+//	@Param		request	body		dto.AssignRoleRequest	true	"Role"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/roles/users/{userId} [post]
+func (h *Handler) Assign(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	userID, ok := parseID(c, "userId")
+	if !ok {
+		return
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Assign(c.Request.Context(), user, userID, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Unassign godoc
+//
+//	@Summary	Remove a role from a user
+//	@Tags		Roles
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		userId	path		string	true	"User ID"
+//	@Param		roleId	path		string	true	"Role ID"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/roles/users/{userId}/{roleId} [delete]
+func (h *Handler) Unassign(c *gin.Context) {
+	userID, ok := parseID(c, "userId")
+	if !ok {
+		return
+	}
+	roleID, ok := parseID(c, "roleId")
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Unassign(c.Request.Context(), userID, roleID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Ban godoc
+//
+//	@Summary		Ban a user
+//	@Description	The user can no longer sign in or refresh tokens, every token it holds is revoked.
+//	@Tags			Roles
+//	@Security		BearerAuth
+//	@Produce		json
+//	@Param			userId	path		string	true	"User ID"
+//	@Success		200		{object}	types.ResponseAPI
+//	@Router			/roles/bans/{userId} [post]
+func (h *Handler) Ban(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	userID, ok := parseID(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Ban(c.Request.Context(), user, userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+// Unban godoc
+//
+//	@Summary	Lift the ban of a user
+//	@Tags		Roles
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		userId	path		string	true	"User ID"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/roles/bans/{userId} [delete]
+func (h *Handler) Unban(c *gin.Context) {
+	userID, ok := parseID(c, "userId")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unban(c.Request.Context(), userID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", nil, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, roleService.ErrRoleNotFound),
+		errors.Is(err, roleService.ErrRoleNotAssigned),
+		errors.Is(err, roleService.ErrUserNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, roleService.ErrInvalidPermission),
+		errors.Is(err, roleService.ErrCannotBanSelf):
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+	case errors.Is(err, roleService.ErrRoleExists):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,27 @@
+package role
+
+import (
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/roles", middleware.AuthMiddleware(h.auth))
+
+	group.GET("/me", h.Mine)
+
+	managed := group.Group("", middleware.RequirePermission(h.service, enum.PERMISSION_ROLES_MANAGE.ToString()))
+	managed.
+		GET("", h.List).
+		POST("", h.Create).
+		GET("/:id", h.Detail).
+		PUT("/:id", h.Update).
+		DELETE("/:id", h.Delete).
+		GET("/users/:userId", h.UserRoles).
+		POST("/users/:userId", h.Assign).
+		DELETE("/users/:userId/:roleId", h.Unassign).
+		POST("/bans/:userId", h.Ban).
+		DELETE("/bans/:userId", h.Unban)
+}
@@ -0,0 +1,99 @@
+package stream
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	streamService "api-stack-underflow/internal/service/stream"
+
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// heartbeatInterval keeps idle connections open through proxies that drop silent streams
+const heartbeatInterval = 15 * time.Second
+
+type Handler struct {
+	service streamService.IStreamService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service streamService.IStreamService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// Stream godoc
+//
+//	@Summary		Live updates of a question
+//	@Description	Server-Sent Events stream of answer_created, answer_accepted, comment_created, score_changed and status_changed events. The stream ends when the client falls behind, reconnect and reload the question.
+//	@Tags			Questions
+//	@Produce		text/event-stream
+//	@Param			id	path	string	true	"Question ID"
+//	@Success		200
+//	@Router			/questions/{id}/stream [get]
+func (h *Handler) Stream(c *gin.Context) {
+	questionID, ok := parseID(c, "id")
+	if !ok {
+		return
+	}
+
+	events, cancel, err := h.service.Subscribe(c.Request.Context(), questionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer cancel()
+
+	c.Header("Content-Type", sse.ContentType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{
+				Id:    event.ID.String(),
+				Event: event.Type.ToString(),
+				Data:  event.Data,
+			})
+			return true
+		}
+	})
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, streamService.ErrQuestionNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseID(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid "+param, nil, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
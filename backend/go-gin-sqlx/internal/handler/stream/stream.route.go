@@ -0,0 +1,11 @@
+package stream
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/questions/:id")
+
+	group.GET("/stream", h.Stream)
+}
@@ -0,0 +1,169 @@
+package tag
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/tag"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	"api-stack-underflow/internal/pkg/pagination"
+	tagService "api-stack-underflow/internal/service/tag"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service tagService.ITagService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service tagService.ITagService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// List godoc
+//
+//	@Summary	List tags with usage counts
+//	@Tags		Tags
+//	@Produce	json
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Param		q			query		string	false	"Search tag name"
+//	@Param		sort_by		query		string	false	"name, usage_count or created_at"
+//	@Param		order		query		string	false	"ASC or DESC"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/tags [get]
+func (h *Handler) List(c *gin.Context) {
+	p, err := pagination.NewPaginationFromQuery(c, tagService.TagPaginationConfig())
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.List(c.Request.Context(), p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Detail godoc
+//
+//	@Summary	Tag page
+//	@Tags		Tags
+//	@Produce	json
+//	@Param		name	path		string	true	"Tag name or synonym"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/tags/{name} [get]
+func (h *Handler) Detail(c *gin.Context) {
+	result, err := h.service.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Create godoc
+//
+//	@Summary	Create tag
+//	@Tags		Tags
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dto.CreateTagRequest	true	"Tag"
+//	@Success	201		{object}	types.ResponseAPI
+//	@Router		/tags [post]
+func (h *Handler) Create(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	var req dto.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Create(c.Request.Context(), user, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Created", result, nil)
+}
+
+// Update godoc
+//
+//	@Summary	Update tag description
+//	@Tags		Tags
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		name	path		string					true	"Tag name"
+//	@Param		request	body		dto.UpdateTagRequest	true	"Tag"
+//	@Success	200		{object}	types.ResponseAPI
+//	@Router		/tags/{name} [put]
+func (h *Handler) Update(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	var req dto.UpdateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Update(c.Request.Context(), user, c.Param("name"), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// AddSynonym godoc
+//
+//	@Summary		Add a synonym to a tag
+//	@Description	A synonym that is a tag of its own is merged, its questions are retagged and it is deleted.
+//	@Tags			Tags
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path		string						true	"Tag name"
+//	@Param			request	body		dto.CreateSynonymRequest	true	"Synonym"
+//	@Success		201		{object}	types.ResponseAPI
+//	@Router			/tags/{name}/synonyms [post]
+func (h *Handler) AddSynonym(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	var req dto.CreateSynonymRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.AddSynonym(c.Request.Context(), user, c.Param("name"), req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusCreated, "Created", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, tagService.ErrTagNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, tagService.ErrTagForbidden):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	case errors.Is(err, tagService.ErrInvalidTagName),
+		errors.Is(err, tagService.ErrTooManyTags):
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+	case errors.Is(err, tagService.ErrTagExists),
+		errors.Is(err, tagService.ErrSynonymExists):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
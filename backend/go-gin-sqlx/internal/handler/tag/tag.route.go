@@ -0,0 +1,21 @@
+package tag
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/tags")
+
+	group.
+		GET("", h.List).
+		GET("/:name", h.Detail)
+
+	protected := group.Group("", middleware.AuthMiddleware(h.auth))
+	protected.
+		POST("", h.Create).
+		PUT("/:name", h.Update).
+		POST("/:name/synonyms", h.AddSynonym)
+}
@@ -0,0 +1,108 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/user"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	"api-stack-underflow/internal/pkg/pagination"
+	userService "api-stack-underflow/internal/service/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service userService.IUserService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service userService.IUserService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// Profile godoc
+//
+//	@Summary	Public profile of a user
+//	@Tags		Users
+//	@Produce	json
+//	@Param		username	path		string	true	"Username"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/users/{username} [get]
+func (h *Handler) Profile(c *gin.Context) {
+	result, err := h.service.Profile(c.Request.Context(), c.Param("username"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Activity godoc
+//
+//	@Summary	Questions, answers and comments of a user, newest first
+//	@Tags		Users
+//	@Produce	json
+//	@Param		username	path		string	true	"Username"
+//	@Param		post_type	query		string	false	"Filter by question, answer or comment"
+//	@Param		page		query		int		false	"Page"
+//	@Param		page_size	query		int		false	"Page size"
+//	@Param		sort_by		query		string	false	"created_at or score"
+//	@Param		order		query		string	false	"ASC or DESC"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/users/{username}/activity [get]
+func (h *Handler) Activity(c *gin.Context) {
+	p, err := pagination.NewPaginationFromQuery(c, userService.ActivityPaginationConfig())
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Activity(c.Request.Context(), c.Param("username"), p)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// UpdateProfile godoc
+//
+//	@Summary		Update the profile of the current user
+//	@Description	A rename is applied to all posts of the user and returns a new access token carrying the new username.
+//	@Tags			Users
+//	@Security		BearerAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		dto.UpdateProfileRequest	true	"Profile"
+//	@Success		200		{object}	types.ResponseAPI
+//	@Router			/users/me [patch]
+func (h *Handler) UpdateProfile(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.UpdateProfile(c.Request.Context(), user, req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, userService.ErrUserNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, userService.ErrUsernameTaken):
+		helper.APIResponse(c, http.StatusConflict, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
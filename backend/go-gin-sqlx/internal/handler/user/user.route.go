@@ -0,0 +1,16 @@
+package user
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	e.PATCH("/users/me", middleware.AuthMiddleware(h.auth), h.UpdateProfile)
+
+	group := e.Group("/users/:username")
+	group.
+		GET("", h.Profile).
+		GET("/activity", h.Activity)
+}
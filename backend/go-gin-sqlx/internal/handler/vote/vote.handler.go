@@ -0,0 +1,119 @@
+package vote
+
+import (
+	"errors"
+	"net/http"
+
+	dto "api-stack-underflow/internal/dto/vote"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/middleware"
+	answerService "api-stack-underflow/internal/service/answer"
+	questionService "api-stack-underflow/internal/service/question"
+	reputationService "api-stack-underflow/internal/service/reputation"
+	voteService "api-stack-underflow/internal/service/vote"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service voteService.IVoteService
+	auth    *jwt.Manager
+}
+
+func NewHandler(service voteService.IVoteService, auth *jwt.Manager) *Handler {
+	return &Handler{service: service, auth: auth}
+}
+
+// Vote godoc
+//
+//	@Summary	Up or down vote a question or answer
+//	@Tags		Votes
+//	@Security	BearerAuth
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string			true	"Question ID"
+//	@Param		answerId	path		string			false	"Answer ID (answer votes only)"
+//	@Param		request		body		dto.VoteRequest	true	"Vote"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/vote [put]
+//	@Router		/questions/{id}/answers/{answerId}/vote [put]
+func (h *Handler) Vote(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	target, ok := parseTarget(c)
+	if !ok {
+		return
+	}
+
+	var req dto.VoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, err.Error(), nil, err)
+		return
+	}
+
+	result, err := h.service.Vote(c.Request.Context(), user, target, req.Direction)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+// Retract godoc
+//
+//	@Summary	Retract a vote
+//	@Tags		Votes
+//	@Security	BearerAuth
+//	@Produce	json
+//	@Param		id			path		string	true	"Question ID"
+//	@Param		answerId	path		string	false	"Answer ID (answer votes only)"
+//	@Success	200			{object}	types.ResponseAPI
+//	@Router		/questions/{id}/vote [delete]
+//	@Router		/questions/{id}/answers/{answerId}/vote [delete]
+func (h *Handler) Retract(c *gin.Context) {
+	user, _ := middleware.CurrentUser(c)
+	target, ok := parseTarget(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Retract(c.Request.Context(), user, target)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	helper.APIResponse(c, http.StatusOK, "Success", result, nil)
+}
+
+func (h *Handler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, questionService.ErrQuestionNotFound),
+		errors.Is(err, answerService.ErrAnswerNotFound):
+		helper.APIResponse(c, http.StatusNotFound, err.Error(), nil, err)
+	case errors.Is(err, voteService.ErrSelfVote),
+		errors.Is(err, reputationService.ErrInsufficientReputation):
+		helper.APIResponse(c, http.StatusForbidden, err.Error(), nil, err)
+	default:
+		helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+	}
+}
+
+func parseTarget(c *gin.Context) (voteService.VoteTarget, bool) {
+	questionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		helper.APIResponse(c, http.StatusBadRequest, "invalid id", nil, err)
+		return voteService.VoteTarget{}, false
+	}
+
+	target := voteService.VoteTarget{QuestionID: questionID}
+	if raw := c.Param("answerId"); raw != "" {
+		answerID, err := uuid.Parse(raw)
+		if err != nil {
+			helper.APIResponse(c, http.StatusBadRequest, "invalid answerId", nil, err)
+			return voteService.VoteTarget{}, false
+		}
+		target.AnswerID = &answerID
+	}
+	return target, true
+}
@@ -0,0 +1,17 @@
+package vote
+
+import (
+	"api-stack-underflow/internal/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) NewRoutes(e *gin.RouterGroup) {
+	group := e.Group("/questions/:id", middleware.AuthMiddleware(h.auth))
+
+	group.
+		PUT("/vote", h.Vote).
+		DELETE("/vote", h.Retract).
+		PUT("/answers/:answerId/vote", h.Vote).
+		DELETE("/answers/:answerId/vote", h.Retract)
+}
@@ -3,9 +3,11 @@ package database
 import (
 	"api-stack-underflow/internal/pkg/helper"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/jackc/pgx/v5/stdlib" // driver pgx untuk database/sql
 	"github.com/jmoiron/sqlx"
 )
@@ -119,3 +121,13 @@ func SelectInContext[T any](ctx context.Context, db DBInterface, dest *[]T, base
 	// Jalankan query
 	return db.SelectContext(ctx, dest, query, expandedArgs...)
 }
+
+// uniqueViolation is the PostgreSQL SQLSTATE of a duplicate key
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err, possibly wrapped, is a duplicate key
+// error, e.g. a concurrent insert winning the race on a UNIQUE constraint
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
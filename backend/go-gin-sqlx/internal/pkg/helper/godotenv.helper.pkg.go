@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func GetEnv(key string) string {
@@ -46,3 +48,28 @@ func GetEnvAsInt(name string, defaultVal int) int {
 	logger.Log.Debug().Msgf("Environment variable %s not set or invalid, using default value: %d\n", name, defaultVal)
 	return defaultVal
 }
+
+func GetEnvAsDuration(name string, defaultVal time.Duration) time.Duration {
+	if val, ok := os.LookupEnv(name); ok {
+		if durationVal, err := time.ParseDuration(val); err == nil {
+			return durationVal
+		}
+	}
+	logger.Log.Debug().Msgf("Environment variable %s not set or invalid, using default value: %s\n", name, defaultVal)
+	return defaultVal
+}
+
+// GetEnvAsSlice splits a comma separated variable, dropping empty entries
+func GetEnvAsSlice(name string, defaultVal []string) []string {
+	if val, ok := os.LookupEnv(name); ok {
+		values := make([]string, 0)
+		for _, item := range strings.Split(val, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	}
+	logger.Log.Debug().Msgf("Environment variable %s not set, using default value: %v\n", name, defaultVal)
+	return defaultVal
+}
@@ -0,0 +1,27 @@
+package helper
+
+import (
+	"regexp"
+	"strings"
+)
+
+// mentionPattern menangkap @username yang tidak menempel pada kata lain (misal email)
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([a-zA-Z0-9_]{3,30})\b`)
+
+// ExtractMentions mengambil username unik yang di-mention pada teks, urut kemunculan.
+// Username dibandingkan case-insensitive seperti pada login.
+func ExtractMentions(content string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(content, -1)
+
+	seen := make(map[string]bool, len(matches))
+	mentions := make([]string, 0, len(matches))
+	for _, m := range matches {
+		key := strings.ToLower(m[1])
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		mentions = append(mentions, m[1])
+	}
+	return mentions
+}
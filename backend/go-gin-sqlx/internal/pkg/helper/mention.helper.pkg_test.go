@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{name: "none", content: "no mentions here", want: []string{}},
+		{name: "start of text", content: "@alice thanks", want: []string{"alice"}},
+		{name: "several in order", content: "cc @bob and @alice_01.", want: []string{"bob", "alice_01"}},
+		{name: "duplicates ignore case", content: "@Bob @bob @BOB", want: []string{"Bob"}},
+		{name: "email is not a mention", content: "mail me at bob@example.com", want: []string{}},
+		{name: "too short", content: "@ab is not valid", want: []string{}},
+		{name: "double at", content: "@@carol", want: []string{}},
+		{name: "after punctuation", content: "(@dave) said", want: []string{"dave"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractMentions(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractMentions(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 7 * 24 * time.Hour
+	// clockSkew tolerated on exp, nbf and iat between instances
+	clockSkew = 30 * time.Second
+)
+
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrInvalidTokenType = errors.New("invalid token type")
+	ErrRevokedToken     = errors.New("token has been revoked")
+	ErrInvalidConfig    = errors.New("invalid jwt configuration")
+)
+
+// Config describes how tokens are signed and what Verify requires of them.
+// HS256 signs with Secret. RS256 and EdDSA sign with the PEM private key and
+// verify with the PEM public key, derived from the private key when not set.
+// Empty Issuer or Audience are neither set nor checked, zero TTLs use the defaults.
+type Config struct {
+	Algorithm      string
+	Secret         string
+	PrivateKeyPath string
+	PublicKeyPath  string
+	Issuer         string
+	Audience       string
+	AccessTTL      time.Duration
+	RefreshTTL     time.Duration
+}
+
+// RevocationStore reports whether a token has been revoked before its expiry,
+// by its id or along with every token of its user
+type RevocationStore interface {
+	IsRevoked(ctx context.Context, claims *Claims) (bool, error)
+}
+
+// Claims is the payload carried by every token issued by the Manager
+type Claims struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	TokenType string    `json:"token_type"`
+	// IssuedAtMicro is iat in microseconds. iat only has second precision, too
+	// coarse to tell a token issued right after a revocation from one before it.
+	IssuedAtMicro int64 `json:"iat_us,omitempty"`
+	gojwt.RegisteredClaims
+}
+
+// IssuedAtTime returns the issue time at the best precision the token carries,
+// the zero time when it has none
+func (c *Claims) IssuedAtTime() time.Time {
+	if c.IssuedAtMicro != 0 {
+		return time.UnixMicro(c.IssuedAtMicro)
+	}
+	if c.IssuedAt != nil {
+		return c.IssuedAt.Time
+	}
+	return time.Time{}
+}
+
+// Manager issues and verifies signed tokens
+type Manager struct {
+	method     gojwt.SigningMethod
+	signKey    any
+	verifyKey  any
+	issuer     string
+	audience   string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	revocation RevocationStore
+}
+
+// New returns an HS256 manager with the default TTLs and no issuer or audience
+func New(secret string) *Manager {
+	return &Manager{
+		method:     gojwt.SigningMethodHS256,
+		signKey:    []byte(secret),
+		verifyKey:  []byte(secret),
+		accessTTL:  defaultAccessTTL,
+		refreshTTL: defaultRefreshTTL,
+	}
+}
+
+// NewFromConfig builds a manager for the configured algorithm, loading the
+// PEM keys of asymmetric algorithms. An HS256 config without a secret is refused.
+func NewFromConfig(cfg Config) (*Manager, error) {
+	m := &Manager{
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+		accessTTL:  cfg.AccessTTL,
+		refreshTTL: cfg.RefreshTTL,
+	}
+	if m.accessTTL <= 0 {
+		m.accessTTL = defaultAccessTTL
+	}
+	if m.refreshTTL <= 0 {
+		m.refreshTTL = defaultRefreshTTL
+	}
+
+	switch strings.ToUpper(cfg.Algorithm) {
+	case "", AlgorithmHS256:
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("%w: HS256 needs a secret", ErrInvalidConfig)
+		}
+		m.method = gojwt.SigningMethodHS256
+		m.signKey = []byte(cfg.Secret)
+		m.verifyKey = []byte(cfg.Secret)
+
+	case AlgorithmRS256:
+		m.method = gojwt.SigningMethodRS256
+		private, err := loadKey(cfg.PrivateKeyPath, "private", func(pem []byte) (crypto.Signer, error) {
+			return gojwt.ParseRSAPrivateKeyFromPEM(pem)
+		})
+		if err != nil {
+			return nil, err
+		}
+		m.signKey = private
+		if m.verifyKey, err = loadPublicKey(cfg.PublicKeyPath, private, func(pem []byte) (crypto.PublicKey, error) {
+			return gojwt.ParseRSAPublicKeyFromPEM(pem)
+		}); err != nil {
+			return nil, err
+		}
+
+	case strings.ToUpper(AlgorithmEdDSA):
+		m.method = gojwt.SigningMethodEdDSA
+		private, err := loadKey(cfg.PrivateKeyPath, "private", func(pem []byte) (crypto.Signer, error) {
+			key, err := gojwt.ParseEdPrivateKeyFromPEM(pem)
+			if err != nil {
+				return nil, err
+			}
+			signer, ok := key.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("unexpected key type %T", key)
+			}
+			return signer, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		m.signKey = private
+		if m.verifyKey, err = loadPublicKey(cfg.PublicKeyPath, private, gojwt.ParseEdPublicKeyFromPEM); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidConfig, cfg.Algorithm)
+	}
+	return m, nil
+}
+
+func loadKey[T any](path, kind string, parse func([]byte) (T, error)) (T, error) {
+	var zero T
+	if path == "" {
+		return zero, fmt.Errorf("%w: %s key path is required", ErrInvalidConfig, kind)
+	}
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return zero, fmt.Errorf("%w: read %s key: %v", ErrInvalidConfig, kind, err)
+	}
+	key, err := parse(pem)
+	if err != nil {
+		return zero, fmt.Errorf("%w: parse %s key: %v", ErrInvalidConfig, kind, err)
+	}
+	return key, nil
+}
+
+// loadPublicKey reads the public key when a path is set, otherwise it derives it from the private key
+func loadPublicKey(path string, private crypto.Signer, parse func([]byte) (crypto.PublicKey, error)) (crypto.PublicKey, error) {
+	if path == "" {
+		return private.Public(), nil
+	}
+	return loadKey(path, "public", parse)
+}
+
+// Algorithm is the signing algorithm of issued tokens
+func (m *Manager) Algorithm() string {
+	return m.method.Alg()
+}
+
+// MaxTTL is the lifetime of the longest lived token issued
+func (m *Manager) MaxTTL() time.Duration {
+	return max(m.accessTTL, m.refreshTTL)
+}
+
+// WithRevocationStore makes Verify reject tokens revoked through the store
+func (m *Manager) WithRevocationStore(store RevocationStore) *Manager {
+	m.revocation = store
+	return m
+}
+
+// GenerateToken signs a token of the given type for the user
+func (m *Manager) GenerateToken(userID uuid.UUID, username, tokenType string) (string, *Claims, error) {
+	ttl := m.accessTTL
+	if tokenType == RefreshToken {
+		ttl = m.refreshTTL
+	}
+
+	now := time.Now()
+	claims := &Claims{
+		UserID:        userID,
+		Username:      username,
+		TokenType:     tokenType,
+		IssuedAtMicro: now.UnixMicro(),
+		RegisteredClaims: gojwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    m.issuer,
+			Subject:   userID.String(),
+			IssuedAt:  gojwt.NewNumericDate(now),
+			NotBefore: gojwt.NewNumericDate(now),
+			ExpiresAt: gojwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	if m.audience != "" {
+		claims.Audience = gojwt.ClaimStrings{m.audience}
+	}
+
+	token, err := gojwt.NewWithClaims(m.method, claims).SignedString(m.signKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("sign token: %w", err)
+	}
+	return token, claims, nil
+}
+
+// Parse verifies the token signature, exp, nbf and iat, and the issuer and
+// audience when configured, and returns its claims
+func (m *Manager) Parse(tokenString, tokenType string) (*Claims, error) {
+	options := []gojwt.ParserOption{
+		gojwt.WithValidMethods([]string{m.method.Alg()}),
+		gojwt.WithExpirationRequired(),
+		gojwt.WithIssuedAt(),
+		gojwt.WithLeeway(clockSkew),
+	}
+	if m.issuer != "" {
+		options = append(options, gojwt.WithIssuer(m.issuer))
+	}
+	if m.audience != "" {
+		options = append(options, gojwt.WithAudience(m.audience))
+	}
+
+	claims := &Claims{}
+	token, err := gojwt.ParseWithClaims(tokenString, claims, func(t *gojwt.Token) (interface{}, error) {
+		return m.verifyKey, nil
+	}, options...)
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	// Every token issued here carries nbf, one without it was not
+	if claims.NotBefore == nil {
+		return nil, fmt.Errorf("%w: missing nbf", ErrInvalidToken)
+	}
+	if claims.TokenType != tokenType {
+		return nil, ErrInvalidTokenType
+	}
+	return claims, nil
+}
+
+// Verify parses the token and additionally rejects it when it has been revoked
+func (m *Manager) Verify(ctx context.Context, tokenString, tokenType string) (*Claims, error) {
+	claims, err := m.Parse(tokenString, tokenType)
+	if err != nil {
+		return nil, err
+	}
+	if m.revocation != nil {
+		revoked, err := m.revocation.IsRevoked(ctx, claims)
+		if err != nil {
+			return nil, fmt.Errorf("check revocation: %w", err)
+		}
+		if revoked {
+			return nil, ErrRevokedToken
+		}
+	}
+	return claims, nil
+}
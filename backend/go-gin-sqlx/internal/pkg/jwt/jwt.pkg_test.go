@@ -0,0 +1,202 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_GenerateAndParse(t *testing.T) {
+	manager := New("test-secret")
+	userID := uuid.New()
+
+	token, claims, err := manager.GenerateToken(userID, "alice", AccessToken)
+	require.NoError(t, err)
+	assert.NotEmpty(t, claims.ID)
+
+	parsed, err := manager.Parse(token, AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, userID, parsed.UserID)
+	assert.Equal(t, "alice", parsed.Username)
+}
+
+func TestManager_IssuedAtKeepsMicroseconds(t *testing.T) {
+	manager := New("test-secret")
+
+	token, claims, err := manager.GenerateToken(uuid.New(), "alice", AccessToken)
+	require.NoError(t, err)
+
+	parsed, err := manager.Parse(token, AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, claims.IssuedAtMicro, parsed.IssuedAtMicro)
+	assert.Equal(t, time.UnixMicro(claims.IssuedAtMicro), parsed.IssuedAtTime())
+	assert.Equal(t, parsed.IssuedAt.Unix(), parsed.IssuedAtTime().Unix())
+
+	// Tokens issued before the claim existed fall back to iat
+	legacy := Claims{RegisteredClaims: gojwt.RegisteredClaims{IssuedAt: parsed.IssuedAt}}
+	assert.Equal(t, parsed.IssuedAt.Time, legacy.IssuedAtTime())
+}
+
+func TestManager_ParseRejectsWrongType(t *testing.T) {
+	manager := New("test-secret")
+
+	token, _, err := manager.GenerateToken(uuid.New(), "alice", RefreshToken)
+	require.NoError(t, err)
+
+	_, err = manager.Parse(token, AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidTokenType)
+}
+
+func TestManager_ParseRejectsForeignSignature(t *testing.T) {
+	token, _, err := New("secret-a").GenerateToken(uuid.New(), "alice", AccessToken)
+	require.NoError(t, err)
+
+	_, err = New("secret-b").Parse(token, AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+type revokedSet map[string]bool
+
+func (r revokedSet) IsRevoked(_ context.Context, claims *Claims) (bool, error) {
+	return r[claims.ID], nil
+}
+
+func TestManager_VerifyRejectsRevoked(t *testing.T) {
+	store := revokedSet{}
+	manager := New("test-secret").WithRevocationStore(store)
+
+	token, claims, err := manager.GenerateToken(uuid.New(), "alice", AccessToken)
+	require.NoError(t, err)
+
+	_, err = manager.Verify(context.Background(), token, AccessToken)
+	require.NoError(t, err)
+
+	store[claims.ID] = true
+	_, err = manager.Verify(context.Background(), token, AccessToken)
+	assert.ErrorIs(t, err, ErrRevokedToken)
+}
+
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func TestNewFromConfig_AsymmetricRoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keys := map[string]crypto.Signer{AlgorithmRS256: rsaKey, AlgorithmEdDSA: edKey}
+	for algorithm, key := range keys {
+		t.Run(algorithm, func(t *testing.T) {
+			privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+			require.NoError(t, err)
+			publicDER, err := x509.MarshalPKIXPublicKey(key.Public())
+			require.NoError(t, err)
+
+			manager, err := NewFromConfig(Config{
+				Algorithm:      algorithm,
+				PrivateKeyPath: writePEM(t, "private.pem", "PRIVATE KEY", privateDER),
+				PublicKeyPath:  writePEM(t, "public.pem", "PUBLIC KEY", publicDER),
+				Issuer:         "stack-underflow",
+				Audience:       "stack-underflow-web",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, algorithm, manager.Algorithm())
+
+			userID := uuid.New()
+			token, _, err := manager.GenerateToken(userID, "alice", AccessToken)
+			require.NoError(t, err)
+
+			parsed, err := manager.Parse(token, AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, userID, parsed.UserID)
+			assert.Equal(t, "stack-underflow", parsed.Issuer)
+
+			// A verifier that only trusts HS256 must not accept the token
+			_, err = New("test-secret").Parse(token, AccessToken)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestNewFromConfig_RejectsInvalidConfig(t *testing.T) {
+	_, err := NewFromConfig(Config{Algorithm: AlgorithmHS256})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewFromConfig(Config{Algorithm: AlgorithmRS256})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewFromConfig(Config{Algorithm: "none", Secret: "test-secret"})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestManager_ParseChecksIssuerAndAudience(t *testing.T) {
+	issuer, err := NewFromConfig(Config{Secret: "test-secret", Issuer: "a", Audience: "web"})
+	require.NoError(t, err)
+	token, _, err := issuer.GenerateToken(uuid.New(), "alice", AccessToken)
+	require.NoError(t, err)
+
+	for _, cfg := range []Config{
+		{Secret: "test-secret", Issuer: "b", Audience: "web"},
+		{Secret: "test-secret", Issuer: "a", Audience: "mobile"},
+	} {
+		verifier, err := NewFromConfig(cfg)
+		require.NoError(t, err)
+		_, err = verifier.Parse(token, AccessToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	}
+}
+
+func TestManager_ParseChecksTimeClaims(t *testing.T) {
+	manager := New("test-secret")
+	sign := func(claims gojwt.RegisteredClaims) string {
+		token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, &Claims{
+			UserID:           uuid.New(),
+			TokenType:        AccessToken,
+			RegisteredClaims: claims,
+		}).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+		return token
+	}
+	now := time.Now()
+
+	tests := map[string]gojwt.RegisteredClaims{
+		"expired": {
+			NotBefore: gojwt.NewNumericDate(now.Add(-2 * time.Hour)),
+			ExpiresAt: gojwt.NewNumericDate(now.Add(-time.Hour)),
+		},
+		"not yet valid": {
+			NotBefore: gojwt.NewNumericDate(now.Add(time.Hour)),
+			ExpiresAt: gojwt.NewNumericDate(now.Add(2 * time.Hour)),
+		},
+		"missing exp": {
+			NotBefore: gojwt.NewNumericDate(now),
+		},
+		"missing nbf": {
+			ExpiresAt: gojwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+	for name, claims := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := manager.Parse(sign(claims), AccessToken)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package markdown
+
+import (
+	"bytes"
+	"html"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// converter memakai CommonMark dari goldmark ditambah strikethrough GFM. Code
+// block berbahasa otomatis diberi class language-<lang> oleh goldmark.
+var converter = goldmark.New(
+	goldmark.WithExtensions(extension.Strikethrough),
+	goldmark.WithRendererOptions(
+		renderer.WithNodeRenderers(util.Prioritized(rawHTMLRenderer{}, 100)),
+	),
+)
+
+// Render mengubah Markdown menjadi HTML yang sudah disanitasi. HTML mentah pada
+// sumber selalu di-escape, tidak pernah diteruskan. Hasil goldmark tetap
+// dilewatkan ke Sanitize sebagai allow-list terakhir.
+func Render(source string) string {
+	var b bytes.Buffer
+	if err := converter.Convert([]byte(source), &b); err != nil {
+		return "<p>" + html.EscapeString(source) + "</p>\n"
+	}
+	return Sanitize(b.String())
+}
+
+// rawHTMLRenderer menggantikan renderer bawaan goldmark yang membuang HTML
+// mentah menjadi komentar, sehingga HTML mentah tampil sebagai teks biasa
+type rawHTMLRenderer struct{}
+
+func (rawHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindRawHTML, renderRawHTML)
+	reg.Register(ast.KindHTMLBlock, renderHTMLBlock)
+}
+
+func renderRawHTML(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkSkipChildren, nil
+	}
+	segments := node.(*ast.RawHTML).Segments
+	for i := 0; i < segments.Len(); i++ {
+		segment := segments.At(i)
+		_, _ = w.WriteString(html.EscapeString(string(segment.Value(source))))
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func renderHTMLBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkSkipChildren, nil
+	}
+	n := node.(*ast.HTMLBlock)
+	var b bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		b.Write(line.Value(source))
+	}
+	if n.HasClosure() {
+		b.Write(n.ClosureLine.Value(source))
+	}
+	_, _ = w.WriteString("<p>" + html.EscapeString(string(bytes.TrimRight(b.Bytes(), "\n"))) + "</p>\n")
+	return ast.WalkSkipChildren, nil
+}
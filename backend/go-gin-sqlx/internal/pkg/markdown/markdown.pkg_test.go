@@ -0,0 +1,142 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "heading and inline",
+			source:   "## Title ##\n\nSome *em*, **strong**, ~~del~~ and `a < b` in snake_case_name.",
+			expected: "<h2>Title</h2>\n<p>Some <em>em</em>, <strong>strong</strong>, <del>del</del> and <code>a &lt; b</code> in snake_case_name.</p>\n",
+		},
+		{
+			name:     "hard break",
+			source:   "first  \nsecond\\\nthird",
+			expected: "<p>first<br>\nsecond<br>\nthird</p>\n",
+		},
+		{
+			name:     "fenced code keeps language",
+			source:   "```Go\nif a < b {}\n```",
+			expected: "<pre><code class=\"language-Go\">if a &lt; b {}\n</code></pre>\n",
+		},
+		{
+			name:     "fenced code drops unsafe language",
+			source:   "```\"><script>\nx\n```",
+			expected: "<pre><code>x\n</code></pre>\n",
+		},
+		{
+			name:     "indented code",
+			source:   "    <b>\n    x",
+			expected: "<pre><code>&lt;b&gt;\nx\n</code></pre>\n",
+		},
+		{
+			name:     "tight nested list",
+			source:   "- one\n  - nested\n- two",
+			expected: "<ul>\n<li>one\n<ul>\n<li>nested</li>\n</ul>\n</li>\n<li>two</li>\n</ul>\n",
+		},
+		{
+			name:     "ordered list start",
+			source:   "3. three\n4. four",
+			expected: "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>\n",
+		},
+		{
+			name:     "loose list",
+			source:   "- a\n\n- b",
+			expected: "<ul>\n<li>\n<p>a</p>\n</li>\n<li>\n<p>b</p>\n</li>\n</ul>\n",
+		},
+		{
+			name:     "blockquote with lazy line",
+			source:   "> quoted\nlazy",
+			expected: "<blockquote>\n<p>quoted\nlazy</p>\n</blockquote>\n",
+		},
+		{
+			name:     "setext heading and rule",
+			source:   "Title\n===\n\n***",
+			expected: "<h1>Title</h1>\n<hr>\n",
+		},
+		{
+			name:     "link image and autolink",
+			source:   "[docs](https://go.dev/doc_(x) \"Go\") ![logo](/logo.png) <https://go.dev>",
+			expected: "<p><a href=\"https://go.dev/doc_(x)\" title=\"Go\" rel=\"nofollow noopener noreferrer\">docs</a> <img src=\"/logo.png\" alt=\"logo\"> <a href=\"https://go.dev\" rel=\"nofollow noopener noreferrer\">https://go.dev</a></p>\n",
+		},
+		{
+			name:     "raw html is escaped",
+			source:   "<script>alert(1)</script><img src=x onerror=alert(1)>",
+			expected: "<p>&lt;script&gt;alert(1)&lt;/script&gt;&lt;img src=x onerror=alert(1)&gt;</p>\n",
+		},
+		{
+			name:     "raw html block is escaped",
+			source:   "<div>\n*x*\n</div>",
+			expected: "<p>&lt;div&gt;\n*x*\n&lt;/div&gt;</p>\n",
+		},
+		{
+			name:     "unsafe link drops url",
+			source:   "[click](JaVaScRiPt:alert(1)) ![x](data:image/svg+xml,hi)",
+			expected: "<p><a rel=\"nofollow noopener noreferrer\">click</a> </p>\n",
+		},
+		{
+			name:     "escaped punctuation",
+			source:   `\*not em\* 2 * 3`,
+			expected: "<p>*not em* 2 * 3</p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Render(tt.source))
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "drops event handlers and unsafe urls",
+			input:    `<a href="javascript:alert(1)" onclick="x">a</a><img src="x.png" onerror="y">`,
+			expected: `<a rel="nofollow noopener noreferrer">a</a><img src="x.png">`,
+		},
+		{
+			name:     "drops script content",
+			input:    `<p>a<script>alert(1)</script><style>p{}</style>b</p>`,
+			expected: `<p>ab</p>`,
+		},
+		{
+			name:     "unknown tags keep their text",
+			input:    `<div><span>text</span></div>`,
+			expected: `text`,
+		},
+		{
+			name:     "balances tags",
+			input:    `<p><em>x</p>y</em></strong>`,
+			expected: `<p><em>x</em></p>y`,
+		},
+		{
+			name:     "only language classes on code",
+			input:    `<code class="language-go">a</code><code class="evil">b</code><pre class="x">c</pre>`,
+			expected: `<code class="language-go">a</code><code>b</code><pre>c</pre>`,
+		},
+		{
+			name:     "image without safe src is dropped",
+			input:    `<img src="vbscript:x" alt="a">`,
+			expected: ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Sanitize(tt.input))
+		})
+	}
+}
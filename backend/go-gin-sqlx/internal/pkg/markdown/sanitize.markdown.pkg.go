@@ -0,0 +1,205 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	_html "golang.org/x/net/html"
+)
+
+// allowedTags adalah allow-list tag beserta atribut yang boleh dipertahankan
+var allowedTags = map[string][]string{
+	"a":          {"href", "title"},
+	"blockquote": nil,
+	"br":         nil,
+	"code":       {"class"},
+	"del":        nil,
+	"em":         nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"img":        {"src", "alt", "title"},
+	"li":         nil,
+	"ol":         {"start"},
+	"p":          nil,
+	"pre":        nil,
+	"strong":     nil,
+	"ul":         nil,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+// droppedTags dibuang beserta isinya, bukan hanya tagnya
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "noembed": true, "template": true, "textarea": true, "title": true,
+	"xmp": true, "svg": true, "math": true,
+}
+
+const (
+	// linkRelAttribute mencegah link dari post menaikkan SEO dan mengakses window.opener
+	linkRelAttribute  = `rel="nofollow noopener noreferrer"`
+	maxAttributeValue = 2048
+)
+
+var (
+	allowedSchemes   = map[string]bool{"http": true, "https": true, "mailto": true}
+	codeClassPattern = regexp.MustCompile(`^language-[A-Za-z0-9_+#.-]{1,32}$`)
+	listStartPattern = regexp.MustCompile(`^[0-9]{1,9}$`)
+)
+
+// Sanitize menyaring HTML dengan allow-list yang ketat. Tag di luar daftar
+// dibuang (teksnya tetap), atribut lain termasuk on* dan style dibuang, URL
+// hanya boleh http, https, mailto atau relatif. Tag yang tidak seimbang ditutup.
+func Sanitize(input string) string {
+	z := _html.NewTokenizer(strings.NewReader(input))
+
+	var b strings.Builder
+	open := []string{}
+	skip := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case _html.ErrorToken:
+			for k := len(open) - 1; k >= 0; k-- {
+				b.WriteString("</" + open[k] + ">")
+			}
+			return b.String()
+
+		case _html.TextToken:
+			if skip == 0 {
+				b.WriteString(html.EscapeString(string(z.Text())))
+			}
+
+		case _html.StartTagToken, _html.SelfClosingTagToken:
+			tok := z.Token()
+			if droppedTags[tok.Data] {
+				if tt == _html.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+			if !writeStartTag(&b, tok) {
+				continue
+			}
+			if !voidTags[tok.Data] {
+				if tt == _html.SelfClosingTagToken {
+					b.WriteString("</" + tok.Data + ">")
+				} else {
+					open = append(open, tok.Data)
+				}
+			}
+
+		case _html.EndTagToken:
+			tok := z.Token()
+			if droppedTags[tok.Data] {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+			// Menutup tag juga menutup tag terbuka di dalamnya; penutup tanpa pembuka diabaikan
+			for k := len(open) - 1; k >= 0; k-- {
+				if open[k] != tok.Data {
+					continue
+				}
+				for ; len(open) > k; open = open[:len(open)-1] {
+					b.WriteString("</" + open[len(open)-1] + ">")
+				}
+				break
+			}
+		}
+	}
+}
+
+// writeStartTag menulis tag dengan atribut yang lolos, false bila tag dibuang
+func writeStartTag(b *strings.Builder, tok _html.Token) bool {
+	allowed, ok := allowedTags[tok.Data]
+	if !ok {
+		return false
+	}
+
+	attrs := []string{}
+	for _, attr := range tok.Attr {
+		if attr.Namespace != "" || len(attr.Val) > maxAttributeValue || !slices.Contains(allowed, attr.Key) {
+			continue
+		}
+
+		value, ok := attr.Val, true
+		switch attr.Key {
+		case "href", "src":
+			value, ok = safeURL(attr.Val)
+		case "class":
+			ok = codeClassPattern.MatchString(attr.Val)
+		case "start":
+			ok = listStartPattern.MatchString(attr.Val)
+			if ok {
+				n, _ := strconv.Atoi(attr.Val)
+				value = strconv.Itoa(n)
+			}
+		}
+		if !ok {
+			continue
+		}
+		attrs = append(attrs, attr.Key+`="`+html.EscapeString(value)+`"`)
+	}
+
+	if tok.Data == "img" && !hasAttribute(attrs, "src") {
+		return false
+	}
+	if tok.Data == "a" {
+		attrs = append(attrs, linkRelAttribute)
+	}
+
+	b.WriteString("<" + tok.Data)
+	for _, attr := range attrs {
+		b.WriteString(" " + attr)
+	}
+	b.WriteString(">")
+	return true
+}
+
+// safeURL mengembalikan URL bila skemanya diizinkan atau URL relatif
+func safeURL(raw string) (string, bool) {
+	value := strings.TrimSpace(raw)
+	if value == "" || len(value) > maxAttributeValue {
+		return "", false
+	}
+	for _, r := range value {
+		if r < 0x20 || r == 0x7f {
+			return "", false
+		}
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "", false
+	}
+	if parsed.Scheme == "" {
+		return value, true
+	}
+	return value, allowedSchemes[strings.ToLower(parsed.Scheme)]
+}
+
+func hasAttribute(attrs []string, key string) bool {
+	for _, attr := range attrs {
+		if strings.HasPrefix(attr, key+"=") {
+			return true
+		}
+	}
+	return false
+}
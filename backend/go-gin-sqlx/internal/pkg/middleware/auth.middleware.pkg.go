@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ContextClaims   = "claims"
+	ContextUserID   = "user_id"
+	ContextUsername = "username"
+	ContextToken    = "access_token"
+)
+
+// AuthMiddleware validates the Bearer access token and stores its claims in the context
+func AuthMiddleware(auth *jwt.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := BearerToken(c)
+		if !ok {
+			helper.APIResponse(c, http.StatusUnauthorized, "missing bearer token", nil, nil)
+			c.Abort()
+			return
+		}
+
+		claims, err := auth.Verify(c.Request.Context(), token, jwt.AccessToken)
+		if err != nil {
+			helper.APIResponse(c, http.StatusUnauthorized, "invalid or expired token", nil, err)
+			c.Abort()
+			return
+		}
+
+		c.Set(ContextClaims, claims)
+		c.Set(ContextUserID, claims.UserID)
+		c.Set(ContextUsername, claims.Username)
+		c.Set(ContextToken, token)
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware stores the claims of a valid Bearer access token like
+// AuthMiddleware, but lets requests without one, or with an invalid one, through anonymously
+func OptionalAuthMiddleware(auth *jwt.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := BearerToken(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		claims, err := auth.Verify(c.Request.Context(), token, jwt.AccessToken)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		c.Set(ContextClaims, claims)
+		c.Set(ContextUserID, claims.UserID)
+		c.Set(ContextUsername, claims.Username)
+		c.Set(ContextToken, token)
+		c.Next()
+	}
+}
+
+// BearerToken extracts the token from the Authorization header
+func BearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return "", false
+	}
+	token := strings.TrimSpace(header[7:])
+	return token, token != ""
+}
+
+// CurrentUser returns the claims set by AuthMiddleware
+func CurrentUser(c *gin.Context) (*jwt.Claims, bool) {
+	value, exists := c.Get(ContextClaims)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(*jwt.Claims)
+	return claims, ok
+}
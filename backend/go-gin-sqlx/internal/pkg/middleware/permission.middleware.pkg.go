@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"api-stack-underflow/internal/pkg/helper"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PermissionChecker reports whether a user holds a permission through one of their roles
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error)
+}
+
+// RequirePermission lets the request through when the user authenticated by
+// AuthMiddleware holds the permission, e.g. "questions:moderate". It must be
+// mounted after AuthMiddleware.
+func RequirePermission(checker PermissionChecker, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := CurrentUser(c)
+		if !ok {
+			helper.APIResponse(c, http.StatusUnauthorized, "missing bearer token", nil, nil)
+			c.Abort()
+			return
+		}
+
+		allowed, err := checker.HasPermission(c.Request.Context(), claims.UserID, permission)
+		if err != nil {
+			helper.APIResponse(c, http.StatusInternalServerError, "Internal Server Error", nil, err)
+			c.Abort()
+			return
+		}
+		if !allowed {
+			helper.APIResponse(c, http.StatusForbidden, "missing permission "+permission, nil, nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"api-stack-underflow/internal/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type grantedPermissions map[uuid.UUID][]string
+
+func (g grantedPermissions) HasPermission(_ context.Context, userID uuid.UUID, permission string) (bool, error) {
+	if g == nil {
+		return false, errors.New("store unavailable")
+	}
+	for _, granted := range g[userID] {
+		if granted == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	moderator := uuid.New()
+	granted := grantedPermissions{moderator: {"questions:moderate"}}
+
+	tests := []struct {
+		name    string
+		checker PermissionChecker
+		claims  *jwt.Claims
+		want    int
+	}{
+		{name: "granted", checker: granted, claims: &jwt.Claims{UserID: moderator}, want: http.StatusOK},
+		{name: "missing permission", checker: granted, claims: &jwt.Claims{UserID: uuid.New()}, want: http.StatusForbidden},
+		{name: "not authenticated", checker: granted, want: http.StatusUnauthorized},
+		{name: "checker failure", checker: grantedPermissions(nil), claims: &jwt.Claims{UserID: moderator}, want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.GET("/", func(c *gin.Context) {
+				if tt.claims != nil {
+					c.Set(ContextClaims, tt.claims)
+				}
+				c.Next()
+			}, RequirePermission(tt.checker, "questions:moderate"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, tt.want, recorder.Code)
+		})
+	}
+}
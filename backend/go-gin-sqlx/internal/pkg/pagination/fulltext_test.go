@@ -0,0 +1,166 @@
+package pagination
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	pkgErrors "api-stack-underflow/internal/pkg/errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFullTextExpressions(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          FullTextConfig
+		argIndex        int
+		expectedMatch   string
+		expectedColumns string
+		wantErr         bool
+	}{
+		{
+			name:            "document with headline",
+			config:          FullTextConfig{Param: "q", Document: "q.search_vector", Headline: "q.description", HeadlineOptions: "StartSel=<b>, StopSel=</b>"},
+			argIndex:        3,
+			expectedMatch:   "q.search_vector @@ websearch_to_tsquery('english', $3)",
+			expectedColumns: "ts_rank(q.search_vector, websearch_to_tsquery('english', $3)) AS rank, ts_headline('english', translate(q.description, '\uE000\uE001', ''), websearch_to_tsquery('english', $3), 'StartSel=<b>, StopSel=</b>') AS headline",
+		},
+		{
+			name:            "without headline",
+			config:          FullTextConfig{Param: "q", Language: "simple", Document: "d.vector"},
+			argIndex:        1,
+			expectedMatch:   "d.vector @@ websearch_to_tsquery('simple', $1)",
+			expectedColumns: "ts_rank(d.vector, websearch_to_tsquery('simple', $1)) AS rank, '' AS headline",
+		},
+		{
+			name:     "invalid language",
+			config:   FullTextConfig{Param: "q", Language: "english'); DROP TABLE users; --", Document: "d.vector"},
+			argIndex: 1,
+			wantErr:  true,
+		},
+		{
+			name:     "missing document",
+			config:   FullTextConfig{Param: "q"},
+			argIndex: 1,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, columns, err := buildFullTextExpressions(tt.config, tt.argIndex)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, pkgErrors.ErrInvalidPaginationParam)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedMatch, match)
+			assert.Equal(t, tt.expectedColumns, columns)
+		})
+	}
+}
+
+func TestSanitizeHeadline(t *testing.T) {
+	tests := []struct {
+		name     string
+		headline string
+		expected string
+	}{
+		{"marks matches", "how to \uE000center\uE001 a div", "how to <mark>center</mark> a div"},
+		{"script", `<script>alert("x")</script> ` + "\uE000center\uE001", `&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt; <mark>center</mark>`},
+		{"img onerror", `<img src=x onerror=alert(1)>` + "\uE000div\uE001", `&lt;img src=x onerror=alert(1)&gt;<mark>div</mark>`},
+		{"typed mark", `<mark onclick=alert(1)>div`, `&lt;mark onclick=alert(1)&gt;div`},
+		{"unbalanced typed mark", "</mark>\uE000div\uE001", "&lt;/mark&gt;<mark>div</mark>"},
+		{"entities", "a &amp; b", "a &amp;amp; b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SanitizeHeadline(tt.headline))
+		})
+	}
+}
+
+func TestFetchFullText(t *testing.T) {
+	type Post struct {
+		ID       int     `db:"id"`
+		Title    string  `db:"title"`
+		Rank     float64 `db:"rank"`
+		Headline string  `db:"headline"`
+	}
+
+	search := FullTextConfig{Param: "q", Document: "p.search_vector", Headline: "p.body"}
+
+	newPagination := func(filters map[string]string) *Pagination {
+		config := NewDefaultPaginationConfig()
+		config.
+			WithFilter("status", WithDataType("string"), WithOperator("="), WithTableAlias("p")).
+			WithSearch("q", FieldConfig{Field: "title", TableAlias: "p"}).
+			WithSort("id", WithSortTableAlias("p")).
+			SetDefaultSort("id", WithSortTableAlias("p"))
+		return &Pagination{
+			Page:             1,
+			PageSize:         10,
+			Offset:           0,
+			SortBy:           "id",
+			Order:            "DESC",
+			Filters:          filters,
+			PaginationConfig: config,
+		}
+	}
+
+	t.Run("ranks matches and keeps other filters", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM posts p WHERE p.status = $1 AND p.search_vector @@ websearch_to_tsquery('english', $2)")).
+			WithArgs("open", "go channels").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT p.id, p.title, ts_rank(p.search_vector, websearch_to_tsquery('english', $2)) AS rank")).
+			WithArgs("open", "go channels").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "rank", "headline"}).
+				AddRow(1, "Go channels", 0.6, "<mark>channels</mark> block"))
+
+		p := newPagination(map[string]string{"q": "go channels", "status": "open"})
+		result, err := FetchFullText[Post](context.Background(), sqlx.NewDb(db, "postgres"), "p.id, p.title", "posts p", search, p)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Total)
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, "<mark>channels</mark> block", result.Data[0].Headline)
+		// The caller's filters must stay untouched
+		assert.Equal(t, "go channels", p.Filters["q"])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("orders by rank before the pagination sort", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM posts p")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta("ORDER BY rank DESC, p.id DESC LIMIT 10 OFFSET 0")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "rank", "headline"}))
+
+		result, err := FetchFullText[Post](context.Background(), sqlx.NewDb(db, "postgres"), "p.id, p.title", "posts p", search, newPagination(map[string]string{"q": "go"}))
+		require.NoError(t, err)
+		assert.Empty(t, result.Data)
+		assert.NotNil(t, result.Data)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("requires a search term", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		_, err = FetchFullText[Post](context.Background(), sqlx.NewDb(db, "postgres"), "p.id, p.title", "posts p", search, newPagination(map[string]string{"q": "   "}))
+		assert.ErrorIs(t, err, pkgErrors.ErrInvalidPaginationParam)
+	})
+}
@@ -106,6 +106,19 @@ func (pc *PaginationConfig) WithFilter(field string, opts ...FilterOption) *Pagi
 	return pc
 }
 
+// WithField mengarahkan filter ke kolom yang namanya berbeda dari key query parameter
+func WithField(column string) FilterOption {
+	return func(c *FieldConfig) {
+		c.Field = column
+	}
+}
+
+// WithSearch menambahkan konfigurasi pencarian ke beberapa field sekaligus
+func (pc *PaginationConfig) WithSearch(field string, fields ...FieldConfig) *PaginationConfig {
+	pc.AllowedSearch[field] = SearchConfig{Fields: fields}
+	return pc
+}
+
 // WithSort menambahkan konfigurasi pengurutan
 func (pc *PaginationConfig) WithSort(field string, opts ...SortOption) *PaginationConfig {
 	config := SortConfig{Field: field}
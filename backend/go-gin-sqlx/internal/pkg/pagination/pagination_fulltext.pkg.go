@@ -0,0 +1,151 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"api-stack-underflow/internal/pkg/errors"
+)
+
+const (
+	DefaultTextSearchLanguage = "english"
+
+	// HeadlineStartSel dan HeadlineStopSel adalah karakter private-use yang menandai kata
+	// yang cocok. Keduanya dibuang dari teks sumber di SQL, jadi tidak mungkin berasal dari user.
+	HeadlineStartSel = "\uE000"
+	HeadlineStopSel  = "\uE001"
+
+	// DefaultHeadlineOptions menandai kata yang cocok dengan HeadlineStartSel/HeadlineStopSel dan membatasi panjang snippet
+	DefaultHeadlineOptions = "StartSel=" + HeadlineStartSel + ", StopSel=" + HeadlineStopSel + ", MaxFragments=2, MaxWords=30, MinWords=10"
+)
+
+// headlineMarks mengganti penanda dari DefaultHeadlineOptions dengan tag <mark> setelah snippet di-escape
+var headlineMarks = strings.NewReplacer(HeadlineStartSel, "<mark>", HeadlineStopSel, "</mark>")
+
+// SanitizeHeadline meng-escape snippet ts_headline sebagai HTML lalu mengubah penanda menjadi <mark>.
+// Snippet diambil dari konten user apa adanya, termasuk tag <mark> yang diketik user, jadi
+// HTML di dalamnya tidak boleh sampai ke client.
+func SanitizeHeadline(headline string) string {
+	return headlineMarks.Replace(html.EscapeString(headline))
+}
+
+// FullTextConfig konfigurasi pencarian full-text Postgres.
+// Document dan Headline adalah ekspresi SQL dari kode, bukan dari input user.
+type FullTextConfig struct {
+	Param           string // query parameter berisi kata kunci, mis. "q"
+	Language        string // text search configuration, default "english"
+	Document        string // ekspresi tsvector yang dicari, mis. "q.search_vector"
+	Headline        string // ekspresi teks untuk snippet ts_headline, kosong = tanpa snippet; hasilnya harus lewat SanitizeHeadline
+	HeadlineOptions string // opsi ts_headline, default DefaultHeadlineOptions
+}
+
+// buildFullTextExpressions membangun kondisi WHERE serta kolom rank dan headline.
+// Kata kunci dikirim sebagai parameter $argIndex dan dipakai ulang di semua ekspresi.
+func buildFullTextExpressions(config FullTextConfig, argIndex int) (string, string, error) {
+	language := config.Language
+	if language == "" {
+		language = DefaultTextSearchLanguage
+	}
+	if !isValidFieldName(language) || config.Document == "" || !isValidQueryString(config.Document) || !isValidQueryString(config.Headline) {
+		return "", "", errors.ErrInvalidPaginationParam
+	}
+
+	tsQuery := fmt.Sprintf("websearch_to_tsquery('%s', $%d)", language, argIndex)
+	match := fmt.Sprintf("%s @@ %s", config.Document, tsQuery)
+	columns := fmt.Sprintf("ts_rank(%s, %s) AS rank", config.Document, tsQuery)
+
+	if config.Headline == "" {
+		columns += ", '' AS headline"
+	} else {
+		options := config.HeadlineOptions
+		if options == "" {
+			options = DefaultHeadlineOptions
+		}
+		options = strings.ReplaceAll(options, "'", "''")
+		// Penanda dibuang dari teks sumber supaya hanya ts_headline yang bisa menghasilkannya
+		source := fmt.Sprintf("translate(%s, '%s%s', '')", config.Headline, HeadlineStartSel, HeadlineStopSel)
+		columns += fmt.Sprintf(", ts_headline('%s', %s, %s, '%s') AS headline", language, source, tsQuery, options)
+	}
+
+	return match, columns, nil
+}
+
+// FetchFullText get data hasil pencarian full-text dengan pagination.
+// Hasil diurutkan berdasarkan rank, sort dari pagination menjadi penentu berikutnya.
+// T harus punya field db "rank" dan "headline" selain kolom yang dipilih.
+func FetchFullText[T any](
+	ctx context.Context,
+	db DBInterface,
+	columns string,
+	from string,
+	search FullTextConfig,
+	pagination *Pagination,
+) (PaginatedResponse[T], error) {
+	config := pagination.PaginationConfig
+	if !isValidQueryString(columns) || !isValidQueryString(from) {
+		return PaginatedResponse[T]{}, errors.ErrInvalidQueryString
+	}
+
+	term := strings.TrimSpace(pagination.Filters[search.Param])
+	if term == "" {
+		return PaginatedResponse[T]{}, fmt.Errorf("%w: search term %s is required", errors.ErrInvalidPaginationParam, search.Param)
+	}
+
+	// Kata kunci ditangani full-text, bukan ILIKE dari AllowedSearch
+	filters := make(map[string]string, len(pagination.Filters))
+	for field, val := range pagination.Filters {
+		if field != search.Param {
+			filters[field] = val
+		}
+	}
+
+	whereClauses, args, err := BuildWhereAndArgs(filters, config.AllowedFilters, config.DefaultFilter, config.AllowedSearch)
+	if err != nil {
+		return PaginatedResponse[T]{}, fmt.Errorf("error building WHERE clauses: %w", err)
+	}
+
+	match, searchColumns, err := buildFullTextExpressions(search, len(args)+1)
+	if err != nil {
+		return PaginatedResponse[T]{}, fmt.Errorf("error building full-text search: %w", err)
+	}
+	whereClauses = append(whereClauses, match)
+	args = append(args, term)
+	where := " WHERE " + strings.Join(whereClauses, " AND ")
+
+	var total int
+	if err := db.GetContext(ctx, &total, "SELECT COUNT(*) FROM "+from+where, args...); err != nil {
+		return PaginatedResponse[T]{}, fmt.Errorf("error getting total count: %w", err)
+	}
+
+	sortConfig, exists := config.AllowedSorts[pagination.SortBy]
+	if !exists {
+		sortConfig = config.DefaultSort
+	}
+	sortField, err := buildFieldExpression(sortConfig.Field, FieldConfig{
+		Field:      sortConfig.Field,
+		TableAlias: sortConfig.TableAlias,
+		Transform:  sortConfig.Transform,
+	})
+	if err != nil {
+		return PaginatedResponse[T]{}, err
+	}
+	order := strings.ToUpper(pagination.Order)
+	if order != "ASC" && order != "DESC" {
+		order = "DESC"
+	}
+
+	query := fmt.Sprintf("SELECT %s, %s FROM %s%s ORDER BY rank DESC, %s %s LIMIT %d OFFSET %d",
+		columns, searchColumns, from, where, sortField, order, pagination.PageSize, pagination.Offset)
+
+	var data []T
+	if err := db.SelectContext(ctx, &data, query, args...); err != nil {
+		return PaginatedResponse[T]{}, fmt.Errorf("error fetching data: %w", err)
+	}
+	if data == nil {
+		data = make([]T, 0)
+	}
+
+	return NewPaginatedResponse(data, total, pagination.Page, pagination.PageSize), nil
+}
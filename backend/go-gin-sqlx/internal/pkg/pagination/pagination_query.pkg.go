@@ -111,6 +111,8 @@ func BuildWhereAndArgs(filters map[string]string, fieldConfigs map[string]FieldC
 				switch config.DataType {
 				case "string":
 					operator = "ILIKE"
+				case "array":
+					operator = "@>"
 				default:
 					operator = "="
 				}
@@ -147,6 +149,15 @@ func BuildWhereAndArgs(filters map[string]string, fieldConfigs map[string]FieldC
 			case "in_year":
 				processedVal = val
 				clauses = append(clauses, fmt.Sprintf("EXTRACT(YEAR FROM %s) = %s", fullFieldName, processedVal))
+			case "array":
+				// Nilai dipisah koma dibandingkan dengan kolom array (@> semua, && salah satu)
+				if !isValidArrayOperator(operator) {
+					return nil, nil, errors.ErrInvalidPaginationParam
+				}
+				if items := splitArrayValue(val); len(items) > 0 {
+					processedVal = items
+					valid = true
+				}
 			default: // string
 				if operator == "ILIKE" {
 					// Escape LIKE special characters dan tambah wildcard
@@ -263,6 +274,33 @@ func BuildWhereAndArgs(filters map[string]string, fieldConfigs map[string]FieldC
 	return clauses, args, nil
 }
 
+// splitArrayValue memecah nilai filter "a,b,c" menjadi elemen array tanpa duplikat
+func splitArrayValue(val string) []string {
+	items := []string{}
+	seen := make(map[string]struct{})
+	for _, item := range strings.Split(val, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if _, exists := seen[item]; exists {
+			continue
+		}
+		seen[item] = struct{}{}
+		items = append(items, item)
+	}
+	return items
+}
+
+// isValidArrayOperator membatasi operator untuk filter bertipe array
+func isValidArrayOperator(operator string) bool {
+	switch operator {
+	case "@>", "&&", "<@":
+		return true
+	}
+	return false
+}
+
 func BuildPaginatedQuery(baseQuery string, whereClauses []string, sortConfig SortConfig, order string, limit int, offset int) (string, error) {
 	query := baseQuery
 
@@ -265,6 +265,33 @@ func TestPaginationConfig_WithSort(t *testing.T) {
 	assert.True(t, sortConfig.NullsLast)
 }
 
+func TestPaginationConfig_WithField(t *testing.T) {
+	config := NewDefaultPaginationConfig()
+
+	config.WithFilter("tagged", WithField("tags"), WithDataType("array"), WithTableAlias("q"))
+
+	filterConfig := config.AllowedFilters["tagged"]
+	assert.Equal(t, "tags", filterConfig.Field)
+	assert.Equal(t, "array", filterConfig.DataType)
+}
+
+func TestPaginationConfig_WithSearch(t *testing.T) {
+	config := NewDefaultPaginationConfig()
+
+	result := config.WithSearch("q",
+		FieldConfig{Field: "title", TableAlias: "q"},
+		FieldConfig{Field: "description", TableAlias: "q"},
+	)
+
+	assert.NotNil(t, result) // Should return pointer for chaining
+	require.Contains(t, config.AllowedSearch, "q")
+
+	searchConfig := config.AllowedSearch["q"]
+	require.Len(t, searchConfig.Fields, 2)
+	assert.Equal(t, "title", searchConfig.Fields[0].Field)
+	assert.Equal(t, "q", searchConfig.Fields[1].TableAlias)
+}
+
 func TestPaginationConfig_SetDefaultSort(t *testing.T) {
 	config := NewDefaultPaginationConfig()
 
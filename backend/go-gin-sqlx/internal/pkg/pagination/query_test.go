@@ -323,6 +323,57 @@ func TestBuildWhereAndArgs(t *testing.T) {
 	}
 }
 
+func TestBuildWhereAndArgs_ArrayFilter(t *testing.T) {
+	t.Run("defaults to containment over deduplicated items", func(t *testing.T) {
+		filters := map[string]string{"tagged": "go, sql,go,"}
+		fieldConfigs := map[string]FieldConfig{
+			"tagged": {Field: "tags", TableAlias: "q", DataType: "array"},
+		}
+
+		clauses, args, err := BuildWhereAndArgs(filters, fieldConfigs, map[string]DefaultFilterField{}, map[string]SearchConfig{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"q.tags @> $1"}, clauses)
+		assert.Equal(t, []interface{}{[]string{"go", "sql"}}, args)
+	})
+
+	t.Run("overlap operator", func(t *testing.T) {
+		filters := map[string]string{"tagged": "go,sql"}
+		fieldConfigs := map[string]FieldConfig{
+			"tagged": {Field: "tags", TableAlias: "q", DataType: "array", Operator: "&&"},
+		}
+
+		clauses, _, err := BuildWhereAndArgs(filters, fieldConfigs, map[string]DefaultFilterField{}, map[string]SearchConfig{})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"q.tags && $1"}, clauses)
+	})
+
+	t.Run("empty list is ignored", func(t *testing.T) {
+		filters := map[string]string{"tagged": " , "}
+		fieldConfigs := map[string]FieldConfig{
+			"tagged": {Field: "tags", TableAlias: "q", DataType: "array"},
+		}
+
+		clauses, args, err := BuildWhereAndArgs(filters, fieldConfigs, map[string]DefaultFilterField{}, map[string]SearchConfig{})
+
+		require.NoError(t, err)
+		assert.Empty(t, clauses)
+		assert.Empty(t, args)
+	})
+
+	t.Run("rejects scalar operator", func(t *testing.T) {
+		filters := map[string]string{"tagged": "go"}
+		fieldConfigs := map[string]FieldConfig{
+			"tagged": {Field: "tags", TableAlias: "q", DataType: "array", Operator: "="},
+		}
+
+		_, _, err := BuildWhereAndArgs(filters, fieldConfigs, map[string]DefaultFilterField{}, map[string]SearchConfig{})
+
+		assert.ErrorIs(t, err, pkgErrors.ErrInvalidPaginationParam)
+	})
+}
+
 func TestBuildPaginatedQuery(t *testing.T) {
 	tests := []struct {
 		name          string
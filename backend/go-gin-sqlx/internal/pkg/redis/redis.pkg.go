@@ -140,3 +140,140 @@ func (r *Client) Expire(key string, expiration time.Duration) error {
 	}
 	return nil
 }
+
+// ReplaceSortedSet atomically swaps all members of a sorted set and sets its expiration.
+// An empty members map removes the key.
+func (r *Client) ReplaceSortedSet(key string, members map[string]float64, expiration time.Duration) error {
+	if len(members) == 0 {
+		return r.Del(key)
+	}
+
+	zs := make([]_redis.Z, 0, len(members))
+	for member, score := range members {
+		zs = append(zs, _redis.Z{Score: score, Member: member})
+	}
+
+	_, err := r.Client.TxPipelined(r.ctx, func(pipe _redis.Pipeliner) error {
+		pipe.Del(r.ctx, key)
+		pipe.ZAdd(r.ctx, key, zs...)
+		pipe.Expire(r.ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace sorted set %s: %w", key, err)
+	}
+	return nil
+}
+
+// ZRevRange returns the members between start and stop, highest score first.
+func (r *Client) ZRevRange(key string, start, stop int64) ([]string, error) {
+	members, err := r.Client.ZRevRange(r.ctx, key, start, stop).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sorted set %s: %w", key, err)
+	}
+	return members, nil
+}
+
+// ZRem removes members from a sorted set.
+func (r *Client) ZRem(key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		args = append(args, member)
+	}
+	if err := r.Client.ZRem(r.ctx, key, args...).Err(); err != nil {
+		return fmt.Errorf("failed to remove members from sorted set %s: %w", key, err)
+	}
+	return nil
+}
+
+// Publish sends value as JSON to every subscriber of a pub/sub channel.
+func (r *Client) Publish(channel string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := r.Client.Publish(r.ctx, channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish to channel %s: %w", channel, err)
+	}
+	return nil
+}
+
+// PSubscribe listens on every channel matching the patterns. The subscription
+// reconnects on its own; the caller closes it when done.
+func (r *Client) PSubscribe(ctx context.Context, patterns ...string) (*_redis.PubSub, error) {
+	pubsub := r.Client.PSubscribe(ctx, patterns...)
+	// Receive waits for the subscription confirmation so errors surface here
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to %v: %w", patterns, err)
+	}
+	return pubsub, nil
+}
+
+// SetNX stores the key only when it does not exist yet and reports whether it was stored.
+func (r *Client) SetNX(key string, value any, expiration time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	stored, err := r.Client.SetNX(r.ctx, key, data, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to set key %s: %w", key, err)
+	}
+	return stored, nil
+}
+
+// HIncrBy increments the integer value of a hash field.
+func (r *Client) HIncrBy(key, field string, incr int64) error {
+	if err := r.Client.HIncrBy(r.ctx, key, field, incr).Err(); err != nil {
+		return fmt.Errorf("failed to increment field %s of hash %s: %w", field, key, err)
+	}
+	return nil
+}
+
+// TakeHash atomically reads every field of a hash and deletes it, so increments
+// made afterwards start a new hash.
+func (r *Client) TakeHash(key string) (map[string]string, error) {
+	var fields *_redis.MapStringStringCmd
+	_, err := r.Client.TxPipelined(r.ctx, func(pipe _redis.Pipeliner) error {
+		fields = pipe.HGetAll(r.ctx, key)
+		pipe.Del(r.ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to take hash %s: %w", key, err)
+	}
+	return fields.Val(), nil
+}
+
+// GetDel atomically retrieves the value of a key and deletes it, "" when the key does not exist.
+func (r *Client) GetDel(key string) (string, error) {
+	result, err := r.Client.GetDel(r.ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, NilType) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get and delete key %s: %w", key, err)
+	}
+	return result, nil
+}
+
+// MGet returns the values of the keys in order, "" for a key that does not exist.
+func (r *Client) MGet(keys ...string) ([]string, error) {
+	results, err := r.Client.MGet(r.ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keys %v: %w", keys, err)
+	}
+
+	values := make([]string, len(results))
+	for i, result := range results {
+		if value, ok := result.(string); ok {
+			values[i] = value
+		}
+	}
+	return values, nil
+}
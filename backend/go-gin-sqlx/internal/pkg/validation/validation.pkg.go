@@ -0,0 +1,116 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	PasswordMinLength = 8
+	PasswordMaxLength = 72 // bcrypt ignores anything past 72 bytes
+)
+
+// ErrNotUnique is returned by Unique when the value is already in use
+var ErrNotUnique = errors.New("value is already in use")
+
+var (
+	usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_]{3,30}$`)
+	draftKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+)
+
+// ExistsLookup reports whether a value is already taken, e.g. a username in su_users
+type ExistsLookup func(ctx context.Context, value string) (bool, error)
+
+// Setup registers the custom tags on gin's validator engine
+func Setup() error {
+	v, err := engine()
+	if err != nil {
+		return err
+	}
+
+	if err := v.RegisterValidation("password", validatePassword); err != nil {
+		return fmt.Errorf("register password validation: %w", err)
+	}
+	if err := v.RegisterValidation("username", validateUsername); err != nil {
+		return fmt.Errorf("register username validation: %w", err)
+	}
+	if err := v.RegisterValidation("draft_key", validateDraftKey); err != nil {
+		return fmt.Errorf("register draft key validation: %w", err)
+	}
+	return nil
+}
+
+// Struct validates a value built outside of request binding with its binding tags
+func Struct(value any) error {
+	return binding.Validator.ValidateStruct(value)
+}
+
+// Unique fails with ErrNotUnique when lookup finds the value already in use.
+// It runs on the request context, a failing lookup is returned as is rather
+// than reported as a taken value.
+func Unique(ctx context.Context, value string, lookup ExistsLookup) error {
+	exists, err := lookup(ctx, value)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrNotUnique
+	}
+	return nil
+}
+
+// IsValidPassword checks the password policy: 8-72 chars with upper, lower and digit
+func IsValidPassword(password string) bool {
+	if len(password) < PasswordMinLength || len(password) > PasswordMaxLength {
+		return false
+	}
+
+	var hasUpper, hasLower, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	return hasUpper && hasLower && hasDigit
+}
+
+// IsValidUsername checks 3-30 chars of letters, digits and underscore
+func IsValidUsername(username string) bool {
+	return usernamePattern.MatchString(username)
+}
+
+// IsValidDraftKey checks 1-64 chars of letters, digits, underscore and dash
+func IsValidDraftKey(key string) bool {
+	return draftKeyPattern.MatchString(key)
+}
+
+func validatePassword(fl validator.FieldLevel) bool {
+	return IsValidPassword(fl.Field().String())
+}
+
+func validateUsername(fl validator.FieldLevel) bool {
+	return IsValidUsername(fl.Field().String())
+}
+
+func validateDraftKey(fl validator.FieldLevel) bool {
+	return IsValidDraftKey(fl.Field().String())
+}
+
+func engine() (*validator.Validate, error) {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil, fmt.Errorf("unexpected validator engine %T", binding.Validator.Engine())
+	}
+	return v, nil
+}
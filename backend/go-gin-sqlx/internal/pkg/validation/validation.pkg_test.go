@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		expected bool
+	}{
+		{"valid", "Secret123", true},
+		{"too short", "Se1", false},
+		{"missing upper", "secret123", false},
+		{"missing lower", "SECRET123", false},
+		{"missing digit", "SecretPass", false},
+		{"too long", "Aa1" + string(make([]byte, 80)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsValidPassword(tt.password))
+		})
+	}
+}
+
+func TestIsValidUsername(t *testing.T) {
+	assert.True(t, IsValidUsername("dev_master"))
+	assert.False(t, IsValidUsername("ab"))
+	assert.False(t, IsValidUsername("has space"))
+	assert.False(t, IsValidUsername("semi;colon"))
+}
+
+func TestIsValidDraftKey(t *testing.T) {
+	assert.True(t, IsValidDraftKey("new-question"))
+	assert.True(t, IsValidDraftKey("answer_3f2a"))
+	assert.False(t, IsValidDraftKey(""))
+	assert.False(t, IsValidDraftKey("drafts:other-user"))
+	assert.False(t, IsValidDraftKey(string(make([]byte, 65))))
+}
+
+func TestSetup(t *testing.T) {
+	require.NoError(t, Setup())
+
+	type request struct {
+		Username string `binding:"required,username"`
+		Password string `binding:"required,password"`
+	}
+
+	assert.NoError(t, binding.Validator.ValidateStruct(request{Username: "fresh_name", Password: "Secret123"}))
+	assert.Error(t, binding.Validator.ValidateStruct(request{Username: "no", Password: "Secret123"}))
+	assert.Error(t, binding.Validator.ValidateStruct(request{Username: "fresh_name", Password: "weak"}))
+}
+
+func TestUnique(t *testing.T) {
+	lookup := func(_ context.Context, value string) (bool, error) {
+		if value == "broken" {
+			return false, errors.New("connection refused")
+		}
+		return value == "taken", nil
+	}
+
+	ctx := context.Background()
+	assert.NoError(t, Unique(ctx, "fresh_name", lookup))
+	assert.ErrorIs(t, Unique(ctx, "taken", lookup), ErrNotUnique)
+
+	err := Unique(ctx, "broken", lookup)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotUnique)
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/pagination"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	answerColumns = `a.id, a.question_id, a.user_id, a.username, a.content, a.is_accepted, a.score, a.created_at, a.updated_at`
+
+	answerBaseQuery  = `SELECT ` + answerColumns + ` FROM su_answers a`
+	answerCountQuery = `SELECT COUNT(*) FROM su_answers a`
+)
+
+// ErrQuestionClosed is returned by Accept when the question was closed before
+// its lock was taken
+var ErrQuestionClosed = errors.New("question is closed")
+
+// EditFunc changes the answer inside the update transaction, see the question
+// repository for the guarantees
+type EditFunc func(ctx context.Context, tx *sqlx.Tx, answer *entity.Answer) error
+
+// AcceptHook runs inside the accept transaction with the previously accepted answer, if any
+type AcceptHook func(ctx context.Context, tx *sqlx.Tx, previous *entity.Answer) error
+
+// DeleteHook runs inside the delete transaction before the row goes, with the
+// answer when it is still the accepted one
+type DeleteHook func(ctx context.Context, tx *sqlx.Tx, accepted *entity.Answer) error
+
+type IAnswerRepository interface {
+	FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Answer], error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Answer, error)
+	Create(ctx context.Context, answer *entity.Answer) error
+	// Update locks the answer, lets edit change it and saves the result
+	Update(ctx context.Context, id uuid.UUID, edit EditFunc) (*entity.Answer, error)
+	Delete(ctx context.Context, answer *entity.Answer, hook DeleteHook) error
+	Accept(ctx context.Context, questionID, answerID uuid.UUID, hook AcceptHook) error
+}
+
+type answerRepository struct {
+	db *database.Database
+}
+
+func NewAnswerRepository(db *database.Database) IAnswerRepository {
+	return &answerRepository{db: db}
+}
+
+func (r *answerRepository) FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Answer], error) {
+	return pagination.FetchPaginated[entity.Answer](ctx, r.db.DB, answerBaseQuery, answerCountQuery, p)
+}
+
+func (r *answerRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Answer, error) {
+	var answer entity.Answer
+	if err := r.db.DB.GetContext(ctx, &answer, answerBaseQuery+` WHERE a.id = $1`, id); err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
+
+func (r *answerRepository) Create(ctx context.Context, answer *entity.Answer) error {
+	// The username is read like in the question repository Create
+	query := `
+		INSERT INTO su_answers (question_id, user_id, username, content)
+		SELECT $1, u.id, u.username, $3
+		FROM su_users u
+		WHERE u.id = $2
+		FOR SHARE
+		RETURNING id, username, is_accepted, score, created_at, updated_at`
+
+	if err := r.db.DB.QueryRowxContext(ctx, query,
+		answer.QuestionID,
+		answer.UserID,
+		answer.Content,
+	).Scan(&answer.ID, &answer.Username, &answer.IsAccepted, &answer.Score, &answer.CreatedAt, &answer.UpdatedAt); err != nil {
+		return fmt.Errorf("insert answer: %w", err)
+	}
+	return nil
+}
+
+func (r *answerRepository) Update(ctx context.Context, id uuid.UUID, edit EditFunc) (*entity.Answer, error) {
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var answer entity.Answer
+	if err := tx.GetContext(ctx, &answer, answerBaseQuery+` WHERE a.id = $1 FOR UPDATE`, id); err != nil {
+		return nil, fmt.Errorf("lock answer: %w", err)
+	}
+	if err := edit(ctx, tx, &answer); err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE su_answers SET content = $1 WHERE id = $2 RETURNING updated_at`
+	if err := tx.QueryRowxContext(ctx, query, answer.Content, answer.ID).Scan(&answer.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("update answer: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
+
+// Delete removes the answer and reopens the question when it was the accepted
+// one. The question is locked like in Accept, so answer.IsAccepted is read fresh,
+// and the answer like in a vote, so no vote slips past the hook.
+func (r *answerRepository) Delete(ctx context.Context, answer *entity.Answer, hook DeleteHook) error {
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM su_questions WHERE id = $1 FOR UPDATE`, answer.QuestionID); err != nil {
+		return fmt.Errorf("lock question: %w", err)
+	}
+	if err := tx.GetContext(ctx, &answer.IsAccepted, `SELECT is_accepted FROM su_answers WHERE id = $1 FOR UPDATE`, answer.ID); err != nil {
+		return err
+	}
+
+	if hook != nil {
+		var accepted *entity.Answer
+		if answer.IsAccepted {
+			accepted = answer
+		}
+		if err := hook(ctx, tx, accepted); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM su_answers WHERE id = $1`, answer.ID); err != nil {
+		return fmt.Errorf("delete answer: %w", err)
+	}
+
+	if answer.IsAccepted {
+		query := `UPDATE su_questions SET status = 'open' WHERE id = $1 AND status = 'answered'`
+		if _, err := tx.ExecContext(ctx, query, answer.QuestionID); err != nil {
+			return fmt.Errorf("reopen question: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Accept marks one answer as accepted, clears any previous one and flags the question as answered
+func (r *answerRepository) Accept(ctx context.Context, questionID, answerID uuid.UUID, hook AcceptHook) error {
+	tx, err := r.db.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the question row so concurrent accepts on the same question serialize,
+	// a close resolving meanwhile is seen here and not overwritten below
+	var status enum.QuestionStatusEnum
+	if err := tx.GetContext(ctx, &status, `SELECT status FROM su_questions WHERE id = $1 FOR UPDATE`, questionID); err != nil {
+		return fmt.Errorf("lock question: %w", err)
+	}
+	if status == enum.QUESTION_CLOSED {
+		return ErrQuestionClosed
+	}
+
+	var previous *entity.Answer
+	var current entity.Answer
+	err = tx.GetContext(ctx, &current, answerBaseQuery+` WHERE a.question_id = $1 AND a.is_accepted`, questionID)
+	switch {
+	case err == nil:
+		previous = &current
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("select accepted answer: %w", err)
+	}
+
+	query := `UPDATE su_answers SET is_accepted = FALSE WHERE question_id = $1 AND is_accepted AND id <> $2`
+	if _, err := tx.ExecContext(ctx, query, questionID, answerID); err != nil {
+		return fmt.Errorf("unaccept previous answer: %w", err)
+	}
+
+	query = `UPDATE su_answers SET is_accepted = TRUE WHERE id = $1 AND question_id = $2`
+	result, err := tx.ExecContext(ctx, query, answerID, questionID)
+	if err != nil {
+		return fmt.Errorf("accept answer: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE su_questions SET status = 'answered' WHERE id = $1`, questionID); err != nil {
+		return fmt.Errorf("mark question answered: %w", err)
+	}
+
+	if hook != nil {
+		if err := hook(ctx, tx, previous); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+
+	"github.com/google/uuid"
+)
+
+const badgeColumns = `ub.id, ub.user_id, ub.badge, ub.post_id, ub.awarded_at`
+
+// Criteria describes who qualifies for a badge. Table and Condition come from
+// the rule catalog in the badge service, never from user input.
+type Criteria struct {
+	// Table holds one row per qualifying action, its user_id column is the earner
+	Table string
+	// Condition is a SQL predicate on Table, empty matches every row
+	Condition string
+	// MinCount is how many matching rows a once-per-user badge needs
+	MinCount int
+	// PerPost awards the badge once for every matching row, keyed by its id
+	PerPost bool
+}
+
+type IBadgeRepository interface {
+	// Award grants badge to everyone meeting criteria and returns only the new
+	// awards. userIDs limits the check to those users, nil checks everyone.
+	Award(ctx context.Context, badge enum.BadgeEnum, criteria Criteria, userIDs []uuid.UUID) ([]entity.UserBadge, error)
+	FindByUser(ctx context.Context, userID uuid.UUID) ([]entity.UserBadge, error)
+	CountAwarded(ctx context.Context) ([]entity.BadgeCount, error)
+}
+
+type badgeRepository struct {
+	db *database.Database
+}
+
+func NewBadgeRepository(db *database.Database) IBadgeRepository {
+	return &badgeRepository{db: db}
+}
+
+func (r *badgeRepository) Award(ctx context.Context, badge enum.BadgeEnum, criteria Criteria, userIDs []uuid.UUID) ([]entity.UserBadge, error) {
+	args := []any{badge}
+	where := "TRUE"
+	if criteria.Condition != "" {
+		where = "(" + criteria.Condition + ")"
+	}
+	if userIDs != nil {
+		ids := make([]string, 0, len(userIDs))
+		for _, id := range userIDs {
+			ids = append(ids, id.String())
+		}
+		args = append(args, ids)
+		where += " AND user_id = ANY($2::uuid[])"
+	}
+
+	var candidates string
+	if criteria.PerPost {
+		candidates = `SELECT user_id, id AS post_id FROM ` + criteria.Table + ` WHERE ` + where
+	} else {
+		candidates = `SELECT user_id, NULL::uuid AS post_id FROM ` + criteria.Table + ` WHERE ` + where +
+			` GROUP BY user_id HAVING COUNT(*) >= ` + strconv.Itoa(max(criteria.MinCount, 1))
+	}
+
+	// A redelivered event or a second backfill hits the unique indexes and awards nothing
+	query := `
+		INSERT INTO su_user_badges AS ub (user_id, badge, post_id)
+		SELECT c.user_id, $1, c.post_id FROM (` + candidates + `) c
+		ON CONFLICT DO NOTHING
+		RETURNING ` + badgeColumns
+
+	awarded := make([]entity.UserBadge, 0)
+	if err := r.db.DB.SelectContext(ctx, &awarded, query, args...); err != nil {
+		return nil, fmt.Errorf("award %s: %w", badge, err)
+	}
+	return awarded, nil
+}
+
+func (r *badgeRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]entity.UserBadge, error) {
+	query := `SELECT ` + badgeColumns + ` FROM su_user_badges ub WHERE ub.user_id = $1 ORDER BY ub.awarded_at DESC, ub.id`
+
+	badges := make([]entity.UserBadge, 0)
+	if err := r.db.DB.SelectContext(ctx, &badges, query, userID); err != nil {
+		return nil, fmt.Errorf("select user badges: %w", err)
+	}
+	return badges, nil
+}
+
+func (r *badgeRepository) CountAwarded(ctx context.Context) ([]entity.BadgeCount, error) {
+	counts := make([]entity.BadgeCount, 0)
+	query := `SELECT badge, COUNT(*) AS count FROM su_user_badges GROUP BY badge ORDER BY badge`
+	if err := r.db.DB.SelectContext(ctx, &counts, query); err != nil {
+		return nil, fmt.Errorf("count badges: %w", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	bountyColumns = `b.id, b.question_id, b.user_id, b.amount, b.status, b.answer_id, b.expires_at, b.resolved_at, b.created_at`
+
+	bountyBaseQuery = `SELECT ` + bountyColumns + ` FROM su_bounties b`
+)
+
+// StartHook runs inside the start transaction with the sponsor's locked reputation
+type StartHook func(ctx context.Context, tx *sqlx.Tx, reputation int) error
+
+// ResolveHook runs inside the resolve transaction with the bounty as it was while active
+type ResolveHook func(ctx context.Context, tx *sqlx.Tx, bounty *entity.Bounty) error
+
+type IBountyRepository interface {
+	FindByQuestion(ctx context.Context, questionID uuid.UUID) ([]entity.Bounty, error)
+	FindActive(ctx context.Context, questionID uuid.UUID) (*entity.Bounty, error)
+	// FindExpired returns active bounties past their deadline, oldest first
+	FindExpired(ctx context.Context, limit int) ([]entity.Bounty, error)
+	// FindAutoAwardAnswer returns the best visible answer posted while the bounty
+	// ran with at least minScore, the sponsor's own answers excluded
+	FindAutoAwardAnswer(ctx context.Context, bounty *entity.Bounty, minScore int) (*entity.Answer, error)
+	// Start inserts the bounty and marks the question. It returns sql.ErrNoRows
+	// when the question is gone, closed, hidden or already has an active bounty.
+	Start(ctx context.Context, bounty *entity.Bounty, hook StartHook) error
+	// Resolve ends an active bounty with status, answerID is set when it is
+	// awarded. It returns sql.ErrNoRows when the bounty is no longer active.
+	Resolve(ctx context.Context, id uuid.UUID, status enum.BountyStatusEnum, answerID *uuid.UUID, hook ResolveHook) (*entity.Bounty, error)
+}
+
+type bountyRepository struct {
+	db *database.Database
+}
+
+func NewBountyRepository(db *database.Database) IBountyRepository {
+	return &bountyRepository{db: db}
+}
+
+func (r *bountyRepository) FindByQuestion(ctx context.Context, questionID uuid.UUID) ([]entity.Bounty, error) {
+	bounties := make([]entity.Bounty, 0)
+	query := bountyBaseQuery + ` WHERE b.question_id = $1 ORDER BY b.created_at DESC`
+	if err := r.db.DB.SelectContext(ctx, &bounties, query, questionID); err != nil {
+		return nil, fmt.Errorf("select bounties: %w", err)
+	}
+	return bounties, nil
+}
+
+func (r *bountyRepository) FindActive(ctx context.Context, questionID uuid.UUID) (*entity.Bounty, error) {
+	var bounty entity.Bounty
+	if err := r.db.DB.GetContext(ctx, &bounty, bountyBaseQuery+` WHERE b.question_id = $1 AND b.status = 'active'`, questionID); err != nil {
+		return nil, err
+	}
+	return &bounty, nil
+}
+
+func (r *bountyRepository) FindExpired(ctx context.Context, limit int) ([]entity.Bounty, error) {
+	bounties := make([]entity.Bounty, 0)
+	query := bountyBaseQuery + ` WHERE b.status = 'active' AND b.expires_at <= NOW() ORDER BY b.expires_at LIMIT $1`
+	if err := r.db.DB.SelectContext(ctx, &bounties, query, limit); err != nil {
+		return nil, fmt.Errorf("select expired bounties: %w", err)
+	}
+	return bounties, nil
+}
+
+func (r *bountyRepository) FindAutoAwardAnswer(ctx context.Context, bounty *entity.Bounty, minScore int) (*entity.Answer, error) {
+	query := `
+		SELECT a.id, a.question_id, a.user_id, a.username, a.content, a.is_accepted, a.score, a.created_at, a.updated_at
+		FROM su_answers a
+		WHERE a.question_id = $1
+		  AND a.created_at >= $2
+		  AND a.score >= $3
+		  AND a.user_id <> $4
+		  AND NOT a.is_hidden
+		ORDER BY a.score DESC, a.created_at
+		LIMIT 1`
+
+	var answer entity.Answer
+	if err := r.db.DB.GetContext(ctx, &answer, query, bounty.QuestionID, bounty.CreatedAt, minScore, bounty.UserID); err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
+
+func (r *bountyRepository) Start(ctx context.Context, bounty *entity.Bounty, hook StartHook) error {
+	tx, err := r.db.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the question so two bounties cannot start together
+	lock := `SELECT id FROM su_questions WHERE id = $1 AND status <> 'closed' AND NOT is_hidden AND bounty_amount = 0 FOR UPDATE`
+	var id uuid.UUID
+	if err := tx.GetContext(ctx, &id, lock, bounty.QuestionID); err != nil {
+		return err
+	}
+
+	// Lock the sponsor so concurrent bounties cannot spend the same reputation
+	var reputation int
+	if err := tx.GetContext(ctx, &reputation, `SELECT reputation FROM su_users WHERE id = $1 FOR UPDATE`, bounty.UserID); err != nil {
+		return fmt.Errorf("lock sponsor: %w", err)
+	}
+
+	query := `
+		INSERT INTO su_bounties (question_id, user_id, amount, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at`
+	if err := tx.QueryRowxContext(ctx, query,
+		bounty.QuestionID,
+		bounty.UserID,
+		bounty.Amount,
+		bounty.ExpiresAt,
+	).Scan(&bounty.ID, &bounty.Status, &bounty.CreatedAt); err != nil {
+		return fmt.Errorf("insert bounty: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE su_questions SET bounty_amount = $1 WHERE id = $2`, bounty.Amount, bounty.QuestionID); err != nil {
+		return fmt.Errorf("mark question bounty: %w", err)
+	}
+
+	if hook != nil {
+		if err := hook(ctx, tx, reputation); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *bountyRepository) Resolve(ctx context.Context, id uuid.UUID, status enum.BountyStatusEnum, answerID *uuid.UUID, hook ResolveHook) (*entity.Bounty, error) {
+	tx, err := r.db.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the bounty so a manual award and the expiry job resolve it exactly once
+	var bounty entity.Bounty
+	if err := tx.GetContext(ctx, &bounty, bountyBaseQuery+` WHERE b.id = $1 AND b.status = 'active' FOR UPDATE`, id); err != nil {
+		return nil, err
+	}
+
+	if hook != nil {
+		if err := hook(ctx, tx, &bounty); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		UPDATE su_bounties
+		SET status = $1, answer_id = $2, resolved_at = NOW()
+		WHERE id = $3
+		RETURNING status, answer_id, resolved_at`
+	if err := tx.QueryRowxContext(ctx, query, status, answerID, id).Scan(&bounty.Status, &bounty.AnswerID, &bounty.ResolvedAt); err != nil {
+		return nil, fmt.Errorf("resolve bounty: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE su_questions SET bounty_amount = 0 WHERE id = $1`, bounty.QuestionID); err != nil {
+		return nil, fmt.Errorf("clear question bounty: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &bounty, nil
+}
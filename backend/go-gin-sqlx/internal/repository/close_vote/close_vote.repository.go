@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/pagination"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	closeVoteColumns = `cv.id, cv.question_id, cv.user_id, cv.username, cv.action, cv.reason, cv.duplicate_of, cv.resolved, cv.created_at`
+
+	closeVoteBaseQuery  = `SELECT ` + closeVoteColumns + ` FROM su_close_votes cv`
+	closeVoteCountQuery = `SELECT COUNT(*) FROM su_close_votes cv`
+)
+
+type ICloseVoteRepository interface {
+	FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.CloseVote], error)
+	HasPending(ctx context.Context, questionID, userID uuid.UUID, action enum.CloseVoteActionEnum) (bool, error)
+	// Cast records the vote and, once needed pending votes are reached, closes or
+	// reopens the question and resolves the votes. It returns the pending count
+	// including this vote and whether the question changed state, or sql.ErrNoRows
+	// when the question is not open for a close vote or not closed for a reopen vote.
+	Cast(ctx context.Context, vote *entity.CloseVote, needed int) (int, bool, error)
+}
+
+type closeVoteRepository struct {
+	db *database.Database
+}
+
+func NewCloseVoteRepository(db *database.Database) ICloseVoteRepository {
+	return &closeVoteRepository{db: db}
+}
+
+func (r *closeVoteRepository) FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.CloseVote], error) {
+	return pagination.FetchPaginated[entity.CloseVote](ctx, r.db.DB, closeVoteBaseQuery, closeVoteCountQuery, p)
+}
+
+func (r *closeVoteRepository) HasPending(ctx context.Context, questionID, userID uuid.UUID, action enum.CloseVoteActionEnum) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM su_close_votes
+			WHERE question_id = $1 AND user_id = $2 AND action = $3 AND NOT resolved
+		)`
+
+	var exists bool
+	if err := r.db.DB.GetContext(ctx, &exists, query, questionID, userID, action); err != nil {
+		return false, fmt.Errorf("check pending close vote: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *closeVoteRepository) Cast(ctx context.Context, vote *entity.CloseVote, needed int) (int, bool, error) {
+	tx, err := r.db.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return 0, false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the question row so the vote that reaches the threshold resolves exactly once.
+	// sql.ErrNoRows means the question is gone or no longer in the state the action needs.
+	lock := `SELECT id FROM su_questions WHERE id = $1 AND (status = 'closed') = $2 FOR UPDATE`
+	var id uuid.UUID
+	if err := tx.GetContext(ctx, &id, lock, vote.QuestionID, vote.Action == enum.CLOSE_VOTE_REOPEN); err != nil {
+		return 0, false, err
+	}
+
+	query := `
+		INSERT INTO su_close_votes (question_id, user_id, username, action, reason, duplicate_of)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, resolved, created_at`
+	if err := tx.QueryRowxContext(ctx, query,
+		vote.QuestionID,
+		vote.UserID,
+		vote.Username,
+		vote.Action,
+		vote.Reason,
+		vote.DuplicateOf,
+	).Scan(&vote.ID, &vote.Resolved, &vote.CreatedAt); err != nil {
+		return 0, false, fmt.Errorf("insert close vote: %w", err)
+	}
+
+	var count int
+	query = `SELECT COUNT(*) FROM su_close_votes WHERE question_id = $1 AND action = $2 AND NOT resolved`
+	if err := tx.GetContext(ctx, &count, query, vote.QuestionID, vote.Action); err != nil {
+		return 0, false, fmt.Errorf("count close votes: %w", err)
+	}
+	if count < needed {
+		return count, false, tx.Commit()
+	}
+
+	switch vote.Action {
+	case enum.CLOSE_VOTE_CLOSE:
+		err = closeQuestion(ctx, tx, vote.QuestionID)
+	case enum.CLOSE_VOTE_REOPEN:
+		err = reopenQuestion(ctx, tx, vote.QuestionID)
+	default:
+		err = fmt.Errorf("unknown close vote action %q", vote.Action)
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	query = `UPDATE su_close_votes SET resolved = TRUE WHERE question_id = $1 AND action = $2 AND NOT resolved`
+	if _, err := tx.ExecContext(ctx, query, vote.QuestionID, vote.Action); err != nil {
+		return 0, false, fmt.Errorf("resolve close votes: %w", err)
+	}
+
+	vote.Resolved = true
+	return count, true, tx.Commit()
+}
+
+// closeQuestion closes with the reason most voters picked, ties go to the most
+// recent vote; a duplicate closure links the most voted original
+func closeQuestion(ctx context.Context, tx *sqlx.Tx, questionID uuid.UUID) error {
+	query := `
+		WITH reason AS (
+			SELECT reason
+			FROM su_close_votes
+			WHERE question_id = $1 AND action = 'close' AND NOT resolved
+			GROUP BY reason
+			ORDER BY COUNT(*) DESC, MAX(created_at) DESC
+			LIMIT 1
+		), original AS (
+			SELECT duplicate_of
+			FROM su_close_votes
+			WHERE question_id = $1 AND action = 'close' AND NOT resolved AND duplicate_of IS NOT NULL
+			GROUP BY duplicate_of
+			ORDER BY COUNT(*) DESC, MAX(created_at) DESC
+			LIMIT 1
+		)
+		UPDATE su_questions
+		SET status = 'closed',
+		    close_reason = (SELECT reason FROM reason),
+		    duplicate_of = CASE
+		        WHEN (SELECT reason FROM reason) = 'duplicate' THEN (SELECT duplicate_of FROM original)
+		    END,
+		    closed_at = NOW()
+		WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, questionID); err != nil {
+		return fmt.Errorf("close question: %w", err)
+	}
+	return nil
+}
+
+// reopenQuestion restores answered when an accepted answer exists, open otherwise
+func reopenQuestion(ctx context.Context, tx *sqlx.Tx, questionID uuid.UUID) error {
+	query := `
+		UPDATE su_questions
+		SET status = CASE
+		        WHEN EXISTS (SELECT 1 FROM su_answers WHERE question_id = $1 AND is_accepted) THEN 'answered'
+		        ELSE 'open'
+		    END,
+		    close_reason = NULL,
+		    duplicate_of = NULL,
+		    closed_at = NULL
+		WHERE id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, questionID); err != nil {
+		return fmt.Errorf("reopen question: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const commentColumns = `id, question_id, user_id, username, content, created_at, updated_at`
+
+// EditFunc changes the comment inside the update transaction, see the question
+// repository for the guarantees
+type EditFunc func(ctx context.Context, tx *sqlx.Tx, comment *entity.Comment) error
+
+type ICommentRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Comment, error)
+	// FindByQuestionID skips comments hidden by moderation
+	FindByQuestionID(ctx context.Context, questionID uuid.UUID) ([]entity.Comment, error)
+	Create(ctx context.Context, comment *entity.Comment) error
+	// Update locks the comment, lets edit change it and saves the result
+	Update(ctx context.Context, id uuid.UUID, edit EditFunc) (*entity.Comment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type commentRepository struct {
+	db *database.Database
+}
+
+func NewCommentRepository(db *database.Database) ICommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Comment, error) {
+	var comment entity.Comment
+	query := `SELECT ` + commentColumns + ` FROM su_comments WHERE id = $1`
+	if err := r.db.DB.GetContext(ctx, &comment, query, id); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *commentRepository) FindByQuestionID(ctx context.Context, questionID uuid.UUID) ([]entity.Comment, error) {
+	comments := make([]entity.Comment, 0)
+	query := `SELECT ` + commentColumns + ` FROM su_comments WHERE question_id = $1 AND NOT is_hidden ORDER BY created_at ASC`
+	if err := r.db.DB.SelectContext(ctx, &comments, query, questionID); err != nil {
+		return nil, fmt.Errorf("select comments: %w", err)
+	}
+	return comments, nil
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *entity.Comment) error {
+	// The username is read like in the question repository Create
+	query := `
+		INSERT INTO su_comments (question_id, user_id, username, content)
+		SELECT $1, u.id, u.username, $3
+		FROM su_users u
+		WHERE u.id = $2
+		FOR SHARE
+		RETURNING id, username, created_at, updated_at`
+
+	if err := r.db.DB.QueryRowxContext(ctx, query,
+		comment.QuestionID,
+		comment.UserID,
+		comment.Content,
+	).Scan(&comment.ID, &comment.Username, &comment.CreatedAt, &comment.UpdatedAt); err != nil {
+		return fmt.Errorf("insert comment: %w", err)
+	}
+	return nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, id uuid.UUID, edit EditFunc) (*entity.Comment, error) {
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var comment entity.Comment
+	if err := tx.GetContext(ctx, &comment, `SELECT `+commentColumns+` FROM su_comments WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return nil, fmt.Errorf("lock comment: %w", err)
+	}
+	if err := edit(ctx, tx, &comment); err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE su_comments SET content = $1 WHERE id = $2 RETURNING updated_at`
+	if err := tx.QueryRowxContext(ctx, query, comment.Content, comment.ID).Scan(&comment.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("update comment: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.DB.ExecContext(ctx, `DELETE FROM su_comments WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("delete comment: %w", err)
+	}
+	return nil
+}
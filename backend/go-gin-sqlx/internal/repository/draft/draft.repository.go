@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/redis"
+
+	"github.com/google/uuid"
+)
+
+const draftKeyPrefix = "drafts:"
+
+// IDraftRepository keeps drafts in Redis, one key per user and draft key.
+// Lookups return a nil draft when there is none or it expired.
+type IDraftRepository interface {
+	Find(ctx context.Context, userID uuid.UUID, key string) (*entity.Draft, error)
+	Save(ctx context.Context, userID uuid.UUID, draft *entity.Draft, ttl time.Duration) error
+	Delete(ctx context.Context, userID uuid.UUID, key string) error
+	// Take reads and removes the draft in one step, of two concurrent calls only one gets it
+	Take(ctx context.Context, userID uuid.UUID, key string) (*entity.Draft, error)
+}
+
+type draftRepository struct {
+	cache *redis.Client
+}
+
+func NewDraftRepository(cache *redis.Client) IDraftRepository {
+	return &draftRepository{cache: cache}
+}
+
+func draftCacheKey(userID uuid.UUID, key string) string {
+	return draftKeyPrefix + userID.String() + ":" + key
+}
+
+func (r *draftRepository) Find(ctx context.Context, userID uuid.UUID, key string) (*entity.Draft, error) {
+	raw, err := r.cache.Get(draftCacheKey(userID, key))
+	if err != nil {
+		return nil, fmt.Errorf("get draft: %w", err)
+	}
+	return decodeDraft(raw)
+}
+
+func (r *draftRepository) Save(ctx context.Context, userID uuid.UUID, draft *entity.Draft, ttl time.Duration) error {
+	if err := r.cache.Set(draftCacheKey(userID, draft.Key), draft, ttl); err != nil {
+		return fmt.Errorf("save draft: %w", err)
+	}
+	return nil
+}
+
+func (r *draftRepository) Delete(ctx context.Context, userID uuid.UUID, key string) error {
+	if err := r.cache.Del(draftCacheKey(userID, key)); err != nil {
+		return fmt.Errorf("delete draft: %w", err)
+	}
+	return nil
+}
+
+func (r *draftRepository) Take(ctx context.Context, userID uuid.UUID, key string) (*entity.Draft, error) {
+	raw, err := r.cache.GetDel(draftCacheKey(userID, key))
+	if err != nil {
+		return nil, fmt.Errorf("take draft: %w", err)
+	}
+	return decodeDraft(raw)
+}
+
+func decodeDraft(raw string) (*entity.Draft, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var draft entity.Draft
+	if err := json.Unmarshal([]byte(raw), &draft); err != nil {
+		return nil, fmt.Errorf("decode draft: %w", err)
+	}
+	return &draft, nil
+}
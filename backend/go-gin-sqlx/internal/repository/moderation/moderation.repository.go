@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/pagination"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	flagColumns = `f.id, f.post_type, f.post_id, f.question_id, f.user_id, f.username, f.reason, f.note, f.status, f.reviewed_by, f.reviewed_at, f.created_at`
+
+	flagBaseQuery  = `SELECT ` + flagColumns + ` FROM su_flags f`
+	flagCountQuery = `SELECT COUNT(*) FROM su_flags f`
+
+	moderationLogColumns = `ml.id, ml.action, ml.post_type, ml.post_id, ml.flag_id, ml.moderator_id, ml.payload, ml.created_at`
+
+	moderationLogBaseQuery  = `SELECT ` + moderationLogColumns + ` FROM su_moderation_logs ml`
+	moderationLogCountQuery = `SELECT COUNT(*) FROM su_moderation_logs ml`
+)
+
+// postTables maps a post type to its table and the expression of its question id
+var postTables = map[enum.PostTypeEnum]struct{ table, questionID string }{
+	enum.POST_TYPE_QUESTION: {table: "su_questions", questionID: "id"},
+	enum.POST_TYPE_ANSWER:   {table: "su_answers", questionID: "question_id"},
+	enum.POST_TYPE_COMMENT:  {table: "su_comments", questionID: "question_id"},
+}
+
+type IModerationRepository interface {
+	FindFlags(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Flag], error)
+	FindFlagByID(ctx context.Context, id uuid.UUID) (*entity.Flag, error)
+	FindLogs(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.ModerationLog], error)
+	// FindPost returns sql.ErrNoRows when the post does not exist
+	FindPost(ctx context.Context, postType enum.PostTypeEnum, postID uuid.UUID) (*entity.ModeratedPost, error)
+	HasPendingFlag(ctx context.Context, postType enum.PostTypeEnum, postID, userID uuid.UUID) (bool, error)
+	// CountFlags counts the pending and approved flags of a post for one reason
+	CountFlags(ctx context.Context, postType enum.PostTypeEnum, postID uuid.UUID, reason enum.FlagReasonEnum) (int, error)
+	// CountApproved counts the approved flags of a post, whatever the reason
+	CountApproved(ctx context.Context, postType enum.PostTypeEnum, postID uuid.UUID) (int, error)
+	CreateFlag(ctx context.Context, flag *entity.Flag) error
+	// Apply reviews the flag (when not nil), sets the post visibility and writes
+	// the log in one transaction. It returns sql.ErrNoRows when the flag was
+	// already reviewed by someone else.
+	Apply(ctx context.Context, flag *entity.Flag, post *entity.ModeratedPost, log *entity.ModerationLog) error
+}
+
+type moderationRepository struct {
+	db *database.Database
+}
+
+func NewModerationRepository(db *database.Database) IModerationRepository {
+	return &moderationRepository{db: db}
+}
+
+func (r *moderationRepository) FindFlags(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Flag], error) {
+	return pagination.FetchPaginated[entity.Flag](ctx, r.db.DB, flagBaseQuery, flagCountQuery, p)
+}
+
+func (r *moderationRepository) FindFlagByID(ctx context.Context, id uuid.UUID) (*entity.Flag, error) {
+	var flag entity.Flag
+	if err := r.db.DB.GetContext(ctx, &flag, flagBaseQuery+` WHERE f.id = $1`, id); err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+func (r *moderationRepository) FindLogs(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.ModerationLog], error) {
+	return pagination.FetchPaginated[entity.ModerationLog](ctx, r.db.DB, moderationLogBaseQuery, moderationLogCountQuery, p)
+}
+
+func (r *moderationRepository) FindPost(ctx context.Context, postType enum.PostTypeEnum, postID uuid.UUID) (*entity.ModeratedPost, error) {
+	source, ok := postTables[postType]
+	if !ok {
+		return nil, fmt.Errorf("unknown post type %q", postType)
+	}
+
+	query := fmt.Sprintf(`SELECT id, %s AS question_id, user_id, is_hidden FROM %s WHERE id = $1`, source.questionID, source.table)
+
+	var post entity.ModeratedPost
+	if err := r.db.DB.GetContext(ctx, &post, query, postID); err != nil {
+		return nil, err
+	}
+	post.Type = postType
+	return &post, nil
+}
+
+func (r *moderationRepository) HasPendingFlag(ctx context.Context, postType enum.PostTypeEnum, postID, userID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM su_flags
+			WHERE post_type = $1 AND post_id = $2 AND user_id = $3 AND status = 'pending'
+		)`
+
+	var exists bool
+	if err := r.db.DB.GetContext(ctx, &exists, query, postType, postID, userID); err != nil {
+		return false, fmt.Errorf("check pending flag: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *moderationRepository) CountFlags(ctx context.Context, postType enum.PostTypeEnum, postID uuid.UUID, reason enum.FlagReasonEnum) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM su_flags
+		WHERE post_type = $1 AND post_id = $2 AND reason = $3 AND status IN ('pending', 'approved')`
+
+	var count int
+	if err := r.db.DB.GetContext(ctx, &count, query, postType, postID, reason); err != nil {
+		return 0, fmt.Errorf("count flags: %w", err)
+	}
+	return count, nil
+}
+
+func (r *moderationRepository) CountApproved(ctx context.Context, postType enum.PostTypeEnum, postID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM su_flags WHERE post_type = $1 AND post_id = $2 AND status = 'approved'`
+
+	var count int
+	if err := r.db.DB.GetContext(ctx, &count, query, postType, postID); err != nil {
+		return 0, fmt.Errorf("count approved flags: %w", err)
+	}
+	return count, nil
+}
+
+func (r *moderationRepository) CreateFlag(ctx context.Context, flag *entity.Flag) error {
+	query := `
+		INSERT INTO su_flags (post_type, post_id, question_id, user_id, username, reason, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, created_at`
+
+	if err := r.db.DB.QueryRowxContext(ctx, query,
+		flag.PostType,
+		flag.PostID,
+		flag.QuestionID,
+		flag.UserID,
+		flag.Username,
+		flag.Reason,
+		flag.Note,
+	).Scan(&flag.ID, &flag.Status, &flag.CreatedAt); err != nil {
+		return fmt.Errorf("insert flag: %w", err)
+	}
+	return nil
+}
+
+func (r *moderationRepository) Apply(ctx context.Context, flag *entity.Flag, post *entity.ModeratedPost, log *entity.ModerationLog) error {
+	tx, err := r.db.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if flag != nil {
+		if err := reviewFlag(ctx, tx, flag); err != nil {
+			return err
+		}
+	}
+
+	source, ok := postTables[post.Type]
+	if !ok {
+		return fmt.Errorf("unknown post type %q", post.Type)
+	}
+	query := fmt.Sprintf(`UPDATE %s SET is_hidden = $1 WHERE id = $2`, source.table)
+	if _, err := tx.ExecContext(ctx, query, post.IsHidden, post.ID); err != nil {
+		return fmt.Errorf("set post visibility: %w", err)
+	}
+
+	query = `
+		INSERT INTO su_moderation_logs (action, post_type, post_id, flag_id, moderator_id, payload)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+	if err := tx.QueryRowxContext(ctx, query,
+		log.Action,
+		log.PostType,
+		log.PostID,
+		log.FlagID,
+		log.ModeratorID,
+		log.Payload,
+	).Scan(&log.ID, &log.CreatedAt); err != nil {
+		return fmt.Errorf("insert moderation log: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// reviewFlag only moves a pending flag, a concurrent review leaves no row to update
+func reviewFlag(ctx context.Context, tx *sqlx.Tx, flag *entity.Flag) error {
+	query := `
+		UPDATE su_flags
+		SET status = $1, reviewed_by = $2, reviewed_at = $3
+		WHERE id = $4 AND status = 'pending'`
+
+	result, err := tx.ExecContext(ctx, query, flag.Status, flag.ReviewedBy, flag.ReviewedAt, flag.ID)
+	if err != nil {
+		return fmt.Errorf("review flag: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("review flag: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
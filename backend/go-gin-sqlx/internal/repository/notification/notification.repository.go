@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+const (
+	notificationColumns = `n.id, n.user_id, n.event_id, n.type, n.event, n.question_id, n.post_type, n.post_id, n.actor_id, n.actor_username, n.is_read, n.read_at, n.created_at`
+
+	notificationBaseQuery  = `SELECT ` + notificationColumns + ` FROM su_notifications n`
+	notificationCountQuery = `SELECT COUNT(*) FROM su_notifications n`
+)
+
+type INotificationRepository interface {
+	FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Notification], error)
+	// CountUnread returns the unread notifications of a user grouped by type
+	CountUnread(ctx context.Context, userID uuid.UUID) ([]entity.UnreadCount, error)
+	// CreateMany skips recipients that already got the event, so a redelivered event is harmless
+	CreateMany(ctx context.Context, notifications []entity.Notification) error
+	// MarkRead returns sql.ErrNoRows when the notification does not belong to the user
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error)
+
+	Follow(ctx context.Context, questionID, userID uuid.UUID) error
+	Unfollow(ctx context.Context, questionID, userID uuid.UUID) error
+	FindFollowers(ctx context.Context, questionID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type notificationRepository struct {
+	db *database.Database
+}
+
+func NewNotificationRepository(db *database.Database) INotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Notification], error) {
+	return pagination.FetchPaginated[entity.Notification](ctx, r.db.DB, notificationBaseQuery, notificationCountQuery, p)
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) ([]entity.UnreadCount, error) {
+	query := `
+		SELECT type, COUNT(*) AS count
+		FROM su_notifications
+		WHERE user_id = $1 AND NOT is_read
+		GROUP BY type
+		ORDER BY type`
+
+	counts := make([]entity.UnreadCount, 0)
+	if err := r.db.DB.SelectContext(ctx, &counts, query, userID); err != nil {
+		return nil, fmt.Errorf("count unread notifications: %w", err)
+	}
+	return counts, nil
+}
+
+func (r *notificationRepository) CreateMany(ctx context.Context, notifications []entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO su_notifications (user_id, event_id, type, event, question_id, post_type, post_id, actor_id, actor_username)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, event_id) DO NOTHING`
+	for _, n := range notifications {
+		if _, err := tx.ExecContext(ctx, query,
+			n.UserID,
+			n.EventID,
+			n.Type,
+			n.Event,
+			n.QuestionID,
+			n.PostType,
+			n.PostID,
+			n.ActorID,
+			n.ActorUsername,
+		); err != nil {
+			return fmt.Errorf("insert notification: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	query := `
+		UPDATE su_notifications
+		SET is_read = TRUE, read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.DB.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("mark notification read: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("mark notification read: %w", err)
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `UPDATE su_notifications SET is_read = TRUE, read_at = NOW() WHERE user_id = $1 AND NOT is_read`
+
+	result, err := r.db.DB.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, fmt.Errorf("mark notifications read: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (r *notificationRepository) Follow(ctx context.Context, questionID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO su_question_follows (question_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (question_id, user_id) DO NOTHING`
+
+	if _, err := r.db.DB.ExecContext(ctx, query, questionID, userID); err != nil {
+		return fmt.Errorf("follow question: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) Unfollow(ctx context.Context, questionID, userID uuid.UUID) error {
+	query := `DELETE FROM su_question_follows WHERE question_id = $1 AND user_id = $2`
+	if _, err := r.db.DB.ExecContext(ctx, query, questionID, userID); err != nil {
+		return fmt.Errorf("unfollow question: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) FindFollowers(ctx context.Context, questionID uuid.UUID) ([]uuid.UUID, error) {
+	followers := make([]uuid.UUID, 0)
+	query := `SELECT user_id FROM su_question_follows WHERE question_id = $1`
+	if err := r.db.DB.SelectContext(ctx, &followers, query, questionID); err != nil {
+		return nil, fmt.Errorf("select followers: %w", err)
+	}
+	return followers, nil
+}
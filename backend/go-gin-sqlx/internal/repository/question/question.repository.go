@@ -0,0 +1,475 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/pagination"
+	"api-stack-underflow/internal/pkg/redis"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	questionColumns = `q.id, q.title, q.description, q.status, q.user_id, q.username, q.score, q.tags, q.view_count, q.bounty_amount, q.close_reason, q.duplicate_of, q.closed_at, q.is_hidden, q.created_at, q.updated_at`
+
+	questionBaseQuery  = `SELECT ` + questionColumns + ` FROM su_questions q`
+	questionCountQuery = `SELECT COUNT(*) FROM su_questions q`
+
+	relatedCacheTTL = 10 * time.Minute
+	// relatedCacheSize is the largest limit the related endpoint accepts, one cache entry serves every limit
+	relatedCacheSize = 50
+
+	hotCacheKey = "questions:hot"
+	hotCacheTTL = 15 * time.Minute
+	// hotSetSize bounds the sorted set, the hot endpoint never asks for more than 50
+	hotSetSize = 200
+
+	// hotScoreExpression decays activity (views, votes, answers) by age in hours,
+	// it expects su_answers joined as a and the rows grouped by q.id
+	hotScoreExpression = `(1 + LN(1 + q.view_count) + q.score + 3 * COUNT(a.id)) / POWER(EXTRACT(EPOCH FROM (NOW() - q.created_at)) / 3600 + 2, 1.5)`
+)
+
+// EditFunc changes the question inside the update transaction. It gets the row
+// as locked there, so edits do not overwrite each other, and writes made with
+// tx, e.g. the revision of the edit, commit or roll back with it.
+type EditFunc func(ctx context.Context, tx *sqlx.Tx, question *entity.Question) error
+
+// DeleteHook runs inside the delete transaction before the question and its
+// answers go, with the accepted answer if there is one
+type DeleteHook func(ctx context.Context, tx *sqlx.Tx, accepted *entity.Answer) error
+
+// questionFullText searches the search_vector kept up to date by triggers on
+// su_questions and su_comments (title, description and comments)
+var questionFullText = pagination.FullTextConfig{
+	Param:    "q",
+	Document: "q.search_vector",
+	Headline: "q.description",
+}
+
+type IQuestionRepository interface {
+	FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Question], error)
+	Search(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.QuestionSearchResult], error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Question, error)
+	Create(ctx context.Context, question *entity.Question) error
+	// Update locks the question, lets edit change it and saves its title,
+	// description and tags. The status and close fields are only written by
+	// the accept and close workflows, an edit never undoes them.
+	Update(ctx context.Context, id uuid.UUID, edit EditFunc) (*entity.Question, error)
+	Delete(ctx context.Context, id uuid.UUID, hook DeleteHook) error
+	FindRelated(ctx context.Context, id uuid.UUID, limit int) ([]entity.Question, error)
+	FindHot(ctx context.Context, limit int) ([]entity.Question, error)
+	RefreshHot(ctx context.Context) error
+	AddViews(ctx context.Context, views map[uuid.UUID]int64) error
+}
+
+type questionRepository struct {
+	db    *database.Database
+	cache *redis.Client
+}
+
+// NewQuestionRepository takes an optional cache, nil disables caching
+func NewQuestionRepository(db *database.Database, cache *redis.Client) IQuestionRepository {
+	return &questionRepository{db: db, cache: cache}
+}
+
+func (r *questionRepository) FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Question], error) {
+	return pagination.FetchPaginated[entity.Question](ctx, r.db.DB, questionBaseQuery, questionCountQuery, p)
+}
+
+func (r *questionRepository) Search(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.QuestionSearchResult], error) {
+	return pagination.FetchFullText[entity.QuestionSearchResult](ctx, r.db.DB, questionColumns, "su_questions q", questionFullText, p)
+}
+
+func (r *questionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Question, error) {
+	var question entity.Question
+	if err := r.db.DB.GetContext(ctx, &question, questionBaseQuery+` WHERE q.id = $1`, id); err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+// Create inserts the question and links its tags, creating unknown tags on the way
+func (r *questionRepository) Create(ctx context.Context, question *entity.Question) error {
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The username comes from su_users, not the token that may predate a
+	// rename. The share lock orders the insert with a concurrent rename.
+	query := `
+		INSERT INTO su_questions (title, description, status, user_id, username, tags)
+		SELECT $1, $2, $3, u.id, u.username, $5
+		FROM su_users u
+		WHERE u.id = $4
+		FOR SHARE
+		RETURNING id, username, score, created_at, updated_at`
+
+	if err := tx.QueryRowxContext(ctx, query,
+		question.Title,
+		question.Description,
+		question.Status,
+		question.UserID,
+		[]string(question.Tags),
+	).Scan(&question.ID, &question.Username, &question.Score, &question.CreatedAt, &question.UpdatedAt); err != nil {
+		return fmt.Errorf("insert question: %w", err)
+	}
+
+	if err := syncQuestionTags(ctx, tx, question); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *questionRepository) Update(ctx context.Context, id uuid.UUID, edit EditFunc) (*entity.Question, error) {
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var question entity.Question
+	if err := tx.GetContext(ctx, &question, questionBaseQuery+` WHERE q.id = $1 FOR UPDATE`, id); err != nil {
+		return nil, fmt.Errorf("lock question: %w", err)
+	}
+	if err := edit(ctx, tx, &question); err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE su_questions
+		SET title = $1, description = $2, tags = $3
+		WHERE id = $4
+		RETURNING updated_at`
+
+	if err := tx.QueryRowxContext(ctx, query,
+		question.Title,
+		question.Description,
+		[]string(question.Tags),
+		question.ID,
+	).Scan(&question.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("update question: %w", err)
+	}
+
+	if err := syncQuestionTags(ctx, tx, &question); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	r.invalidateRelated(question.ID)
+	return &question, nil
+}
+
+// syncQuestionTags makes su_question_tags match the denormalized tags column
+func syncQuestionTags(ctx context.Context, tx *sqlx.Tx, question *entity.Question) error {
+	tags := []string(question.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+
+	query := `
+		INSERT INTO su_tags (name, created_by)
+		SELECT UNNEST($1::text[]), $2
+		ON CONFLICT (name) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, tags, question.UserID); err != nil {
+		return fmt.Errorf("create tags: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM su_question_tags WHERE question_id = $1`, question.ID); err != nil {
+		return fmt.Errorf("clear question tags: %w", err)
+	}
+
+	query = `
+		INSERT INTO su_question_tags (question_id, tag_id)
+		SELECT $1, id FROM su_tags WHERE name = ANY($2)`
+	if _, err := tx.ExecContext(ctx, query, question.ID, tags); err != nil {
+		return fmt.Errorf("link question tags: %w", err)
+	}
+	return nil
+}
+
+func (r *questionRepository) Delete(ctx context.Context, id uuid.UUID, hook DeleteHook) error {
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locked like in an accept and a vote, neither the accepted answer nor the
+	// votes can change under the hook
+	var locked uuid.UUID
+	if err := tx.GetContext(ctx, &locked, `SELECT id FROM su_questions WHERE id = $1 FOR UPDATE`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM su_answers WHERE question_id = $1 FOR UPDATE`, id); err != nil {
+		return fmt.Errorf("lock answers: %w", err)
+	}
+
+	if hook != nil {
+		var accepted *entity.Answer
+		var answer entity.Answer
+		query := `SELECT id, question_id, user_id, username, content, is_accepted, score, created_at, updated_at FROM su_answers WHERE question_id = $1 AND is_accepted`
+		err := tx.GetContext(ctx, &answer, query, id)
+		switch {
+		case err == nil:
+			accepted = &answer
+		case !errors.Is(err, sql.ErrNoRows):
+			return fmt.Errorf("select accepted answer: %w", err)
+		}
+		if err := hook(ctx, tx, accepted); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM su_questions WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("delete question: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	r.invalidateRelated(id)
+	r.removeHot(id)
+	return nil
+}
+
+// FindRelated ranks other questions by shared tags, trigram title similarity
+// and text-search overlap with the source title. Questions closed as a
+// duplicate are left out. The ranked ids are cached per source question, the
+// rows are always read fresh so questions deleted, hidden or closed as a
+// duplicate since then drop out right away.
+func (r *questionRepository) FindRelated(ctx context.Context, id uuid.UUID, limit int) ([]entity.Question, error) {
+	if ids, ok := r.cachedRelated(id); ok {
+		questions, err := r.findByIDsOrdered(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		return truncateQuestions(withoutDuplicates(questions), limit), nil
+	}
+
+	// The source title becomes an OR query so a single shared term counts
+	query := `
+		WITH src AS (
+			SELECT s.id, s.title, s.tags,
+			       to_tsquery('english', NULLIF(REPLACE(plainto_tsquery('english', s.title)::text, ' & ', ' | '), '')) AS query
+			FROM su_questions s
+			WHERE s.id = $1
+		)
+		SELECT ` + questionColumns + `
+		FROM su_questions q
+		CROSS JOIN src
+		WHERE q.id <> src.id
+		  AND NOT q.is_hidden
+		  AND NOT (q.status = 'closed' AND q.duplicate_of IS NOT NULL)
+		  AND (q.tags && src.tags OR q.title % src.title OR q.search_vector @@ src.query)
+		ORDER BY
+			2.0 * CARDINALITY(ARRAY(SELECT UNNEST(q.tags) INTERSECT SELECT UNNEST(src.tags)))
+			+ 3.0 * SIMILARITY(q.title, src.title)
+			+ COALESCE(ts_rank(q.search_vector, src.query), 0) DESC,
+			q.score DESC,
+			q.created_at DESC
+		LIMIT $2`
+
+	questions := make([]entity.Question, 0)
+	if err := r.db.DB.SelectContext(ctx, &questions, query, id, relatedCacheSize); err != nil {
+		return nil, fmt.Errorf("select related questions: %w", err)
+	}
+
+	r.cacheRelated(id, questions)
+	return truncateQuestions(questions, limit), nil
+}
+
+func relatedCacheKey(id uuid.UUID) string {
+	return "questions:related:" + id.String()
+}
+
+// cachedRelated treats every cache failure as a miss, the database stays the source of truth
+func (r *questionRepository) cachedRelated(id uuid.UUID) ([]string, bool) {
+	if r.cache == nil {
+		return nil, false
+	}
+
+	raw, err := r.cache.Get(relatedCacheKey(id))
+	if err != nil {
+		logger.Log.Warn().Err(err).Str("question_id", id.String()).Msg("Failed to read related questions cache")
+		return nil, false
+	}
+	if raw == "" {
+		return nil, false
+	}
+
+	var ids []string
+	if err := json.Unmarshal([]byte(raw), &ids); err != nil {
+		logger.Log.Warn().Err(err).Str("question_id", id.String()).Msg("Failed to decode related questions cache")
+		return nil, false
+	}
+	return ids, true
+}
+
+func (r *questionRepository) cacheRelated(id uuid.UUID, questions []entity.Question) {
+	if r.cache == nil {
+		return
+	}
+	ids := make([]string, len(questions))
+	for i, question := range questions {
+		ids[i] = question.ID.String()
+	}
+	if err := r.cache.Set(relatedCacheKey(id), ids, relatedCacheTTL); err != nil {
+		logger.Log.Warn().Err(err).Str("question_id", id.String()).Msg("Failed to cache related questions")
+	}
+}
+
+// invalidateRelated drops the cached list of a question whose title, tags or text changed
+func (r *questionRepository) invalidateRelated(id uuid.UUID) {
+	if r.cache == nil {
+		return
+	}
+	if err := r.cache.Del(relatedCacheKey(id)); err != nil {
+		logger.Log.Warn().Err(err).Str("question_id", id.String()).Msg("Failed to invalidate related questions cache")
+	}
+}
+
+func (r *questionRepository) removeHot(id uuid.UUID) {
+	if r.cache == nil {
+		return
+	}
+	if err := r.cache.ZRem(hotCacheKey, id.String()); err != nil {
+		logger.Log.Warn().Err(err).Str("question_id", id.String()).Msg("Failed to remove question from hot ranking")
+	}
+}
+
+// withoutDuplicates drops questions closed as a duplicate of another one
+func withoutDuplicates(questions []entity.Question) []entity.Question {
+	kept := questions[:0]
+	for _, question := range questions {
+		if question.Status == enum.QUESTION_CLOSED && question.DuplicateOf != nil {
+			continue
+		}
+		kept = append(kept, question)
+	}
+	return kept
+}
+
+func truncateQuestions(questions []entity.Question, limit int) []entity.Question {
+	if limit > 0 && len(questions) > limit {
+		return questions[:limit]
+	}
+	return questions
+}
+
+// FindHot reads the ranking kept in Redis by RefreshHot and falls back to
+// scoring in the database when Redis is unavailable or the set is empty
+func (r *questionRepository) FindHot(ctx context.Context, limit int) ([]entity.Question, error) {
+	if r.cache != nil {
+		members, err := r.cache.ZRevRange(hotCacheKey, 0, int64(limit-1))
+		if err != nil {
+			logger.Log.Warn().Err(err).Msg("Failed to read hot questions, falling back to database")
+		} else if len(members) > 0 {
+			return r.findByIDsOrdered(ctx, members)
+		}
+	}
+
+	query := `
+		SELECT ` + questionColumns + `
+		FROM su_questions q
+		LEFT JOIN su_answers a ON a.question_id = q.id
+		WHERE q.status <> 'closed' AND NOT q.is_hidden
+		GROUP BY q.id
+		ORDER BY ` + hotScoreExpression + ` DESC
+		LIMIT $1`
+
+	questions := make([]entity.Question, 0)
+	if err := r.db.DB.SelectContext(ctx, &questions, query, limit); err != nil {
+		return nil, fmt.Errorf("select hot questions: %w", err)
+	}
+	return questions, nil
+}
+
+// RefreshHot recomputes the decayed scores and replaces the Redis sorted set.
+// The set expires after hotCacheTTL so a stopped job falls back to the database.
+func (r *questionRepository) RefreshHot(ctx context.Context) error {
+	if r.cache == nil {
+		return nil
+	}
+
+	query := `
+		SELECT q.id, ` + hotScoreExpression + ` AS hot_score
+		FROM su_questions q
+		LEFT JOIN su_answers a ON a.question_id = q.id
+		WHERE q.status <> 'closed' AND NOT q.is_hidden
+		GROUP BY q.id
+		ORDER BY hot_score DESC
+		LIMIT $1`
+
+	var scores []struct {
+		ID    uuid.UUID `db:"id"`
+		Score float64   `db:"hot_score"`
+	}
+	if err := r.db.DB.SelectContext(ctx, &scores, query, hotSetSize); err != nil {
+		return fmt.Errorf("select hot scores: %w", err)
+	}
+
+	members := make(map[string]float64, len(scores))
+	for _, s := range scores {
+		members[s.ID.String()] = s.Score
+	}
+	return r.cache.ReplaceSortedSet(hotCacheKey, members, hotCacheTTL)
+}
+
+// AddViews adds the aggregated view counts in a single statement, ids of
+// questions deleted in the meantime are ignored
+func (r *questionRepository) AddViews(ctx context.Context, views map[uuid.UUID]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(views))
+	counts := make([]int64, 0, len(views))
+	for id, count := range views {
+		ids = append(ids, id.String())
+		counts = append(counts, count)
+	}
+
+	query := `
+		UPDATE su_questions q
+		SET view_count = q.view_count + v.views
+		FROM UNNEST($1::uuid[], $2::bigint[]) AS v(id, views)
+		WHERE q.id = v.id`
+	if _, err := r.db.DB.ExecContext(ctx, query, ids, counts); err != nil {
+		return fmt.Errorf("add question views: %w", err)
+	}
+	return nil
+}
+
+// findByIDsOrdered loads questions keeping the order of ids, ids that no longer
+// exist or were hidden since they were cached are skipped
+func (r *questionRepository) findByIDsOrdered(ctx context.Context, ids []string) ([]entity.Question, error) {
+	var questions []entity.Question
+	if err := r.db.DB.SelectContext(ctx, &questions, questionBaseQuery+` WHERE q.id = ANY($1::uuid[]) AND NOT q.is_hidden`, ids); err != nil {
+		return nil, fmt.Errorf("select questions by id: %w", err)
+	}
+
+	byID := make(map[string]entity.Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID.String()] = q
+	}
+
+	ordered := make([]entity.Question, 0, len(ids))
+	for _, id := range ids {
+		if q, ok := byID[id]; ok {
+			ordered = append(ordered, q)
+		}
+	}
+	return ordered, nil
+}
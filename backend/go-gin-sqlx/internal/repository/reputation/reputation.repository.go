@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/pagination"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	reputationColumns = `r.id, r.user_id, r.amount, r.reason, r.post_type, r.post_id, r.actor_id, r.created_at`
+
+	reputationBaseQuery  = `SELECT ` + reputationColumns + ` FROM su_reputation_events r`
+	reputationCountQuery = `SELECT COUNT(*) FROM su_reputation_events r`
+)
+
+type IReputationRepository interface {
+	FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.ReputationEvent], error)
+	GetReputation(ctx context.Context, userID uuid.UUID) (int, error)
+	// Apply writes ledger rows and moves su_users.reputation inside the caller's transaction
+	Apply(ctx context.Context, tx *sqlx.Tx, events []entity.ReputationEvent) error
+}
+
+type reputationRepository struct {
+	db *database.Database
+}
+
+func NewReputationRepository(db *database.Database) IReputationRepository {
+	return &reputationRepository{db: db}
+}
+
+func (r *reputationRepository) FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.ReputationEvent], error) {
+	return pagination.FetchPaginated[entity.ReputationEvent](ctx, r.db.DB, reputationBaseQuery, reputationCountQuery, p)
+}
+
+func (r *reputationRepository) GetReputation(ctx context.Context, userID uuid.UUID) (int, error) {
+	var reputation int
+	if err := r.db.DB.GetContext(ctx, &reputation, `SELECT reputation FROM su_users WHERE id = $1`, userID); err != nil {
+		return 0, err
+	}
+	return reputation, nil
+}
+
+func (r *reputationRepository) Apply(ctx context.Context, tx *sqlx.Tx, events []entity.ReputationEvent) error {
+	for _, event := range events {
+		if event.Amount == 0 {
+			continue
+		}
+
+		query := `
+			INSERT INTO su_reputation_events (user_id, amount, reason, post_type, post_id, actor_id)
+			VALUES ($1, $2, $3, $4, $5, $6)`
+		if _, err := tx.ExecContext(ctx, query,
+			event.UserID,
+			event.Amount,
+			event.Reason,
+			event.PostType,
+			event.PostID,
+			event.ActorID,
+		); err != nil {
+			return fmt.Errorf("insert reputation event: %w", err)
+		}
+
+		query = `UPDATE su_users SET reputation = reputation + $1 WHERE id = $2`
+		if _, err := tx.ExecContext(ctx, query, event.Amount, event.UserID); err != nil {
+			return fmt.Errorf("update reputation: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/pagination"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	revisionColumns = `rv.id, rv.question_id, rv.post_type, rv.post_id, rv.user_id, rv.username, rv.payload, rv.created_at`
+
+	revisionBaseQuery  = `SELECT ` + revisionColumns + ` FROM su_revisions rv`
+	revisionCountQuery = `SELECT COUNT(*) FROM su_revisions rv`
+
+	// su_visible_revisions leaves out the revisions of hidden posts
+	visibleRevisionBaseQuery  = `SELECT ` + revisionColumns + ` FROM su_visible_revisions rv`
+	visibleRevisionCountQuery = `SELECT COUNT(*) FROM su_visible_revisions rv`
+)
+
+type IRevisionRepository interface {
+	// FindAll lists the revisions of visible posts only
+	FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Revision], error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Revision, error)
+	// FindByPost returns every revision of a post, oldest first
+	FindByPost(ctx context.Context, postType enum.PostTypeEnum, postID uuid.UUID) ([]entity.Revision, error)
+	// Create writes within the transaction of the edit the revision describes
+	Create(ctx context.Context, tx *sqlx.Tx, revision *entity.Revision) error
+}
+
+type revisionRepository struct {
+	db *database.Database
+}
+
+func NewRevisionRepository(db *database.Database) IRevisionRepository {
+	return &revisionRepository{db: db}
+}
+
+func (r *revisionRepository) FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Revision], error) {
+	return pagination.FetchPaginated[entity.Revision](ctx, r.db.DB, visibleRevisionBaseQuery, visibleRevisionCountQuery, p)
+}
+
+func (r *revisionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Revision, error) {
+	var revision entity.Revision
+	if err := r.db.DB.GetContext(ctx, &revision, revisionBaseQuery+` WHERE rv.id = $1`, id); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (r *revisionRepository) FindByPost(ctx context.Context, postType enum.PostTypeEnum, postID uuid.UUID) ([]entity.Revision, error) {
+	query := revisionBaseQuery + `
+		WHERE rv.post_type = $1 AND rv.post_id = $2
+		ORDER BY rv.created_at ASC, rv.id ASC`
+
+	revisions := make([]entity.Revision, 0)
+	if err := r.db.DB.SelectContext(ctx, &revisions, query, postType, postID); err != nil {
+		return nil, fmt.Errorf("select post revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+func (r *revisionRepository) Create(ctx context.Context, tx *sqlx.Tx, revision *entity.Revision) error {
+	query := `
+		INSERT INTO su_revisions (question_id, post_type, post_id, user_id, username, payload)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	if err := tx.QueryRowxContext(ctx, query,
+		revision.QuestionID,
+		revision.PostType,
+		revision.PostID,
+		revision.UserID,
+		revision.Username,
+		revision.Payload,
+	).Scan(&revision.ID, &revision.CreatedAt); err != nil {
+		return fmt.Errorf("insert revision: %w", err)
+	}
+	return nil
+}
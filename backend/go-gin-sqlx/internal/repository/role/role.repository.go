@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/redis"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	roleColumns = `ro.id, ro.code, ro.name, ro.description, ro.is_active, ro.created_at, ro.updated_at`
+
+	roleBaseQuery = `SELECT ` + roleColumns + ` FROM su_roles ro`
+
+	permissionCacheTTL = 10 * time.Minute
+)
+
+type IRoleRepository interface {
+	FindAll(ctx context.Context) ([]entity.Role, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Role, error)
+	FindByCode(ctx context.Context, code string) (*entity.Role, error)
+	// FindByUser returns the roles assigned to the user, inactive ones included
+	FindByUser(ctx context.Context, userID uuid.UUID) ([]entity.Role, error)
+	Create(ctx context.Context, role *entity.Role) error
+	// Update saves the role and replaces its permissions
+	Update(ctx context.Context, role *entity.Role) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Assign gives the role to the user, assigning it twice is a no-op
+	Assign(ctx context.Context, userID, roleID uuid.UUID, assignedBy *uuid.UUID) error
+	// Unassign returns sql.ErrNoRows when the user does not have the role
+	Unassign(ctx context.Context, userID, roleID uuid.UUID) error
+	// FindUserPermissions returns what the active roles of the user grant. The
+	// result is cached per user and dropped whenever a role or assignment changes.
+	FindUserPermissions(ctx context.Context, userID uuid.UUID) ([]enum.PermissionEnum, error)
+}
+
+type roleRepository struct {
+	db    *database.Database
+	cache *redis.Client
+}
+
+// NewRoleRepository takes an optional cache, without it permissions are read
+// from the database on every check
+func NewRoleRepository(db *database.Database, cache *redis.Client) IRoleRepository {
+	return &roleRepository{db: db, cache: cache}
+}
+
+func (r *roleRepository) FindAll(ctx context.Context) ([]entity.Role, error) {
+	roles := make([]entity.Role, 0)
+	if err := r.db.DB.SelectContext(ctx, &roles, roleBaseQuery+` ORDER BY ro.code`); err != nil {
+		return nil, fmt.Errorf("select roles: %w", err)
+	}
+	if err := r.loadPermissions(ctx, roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *roleRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Role, error) {
+	return r.findOne(ctx, roleBaseQuery+` WHERE ro.id = $1`, id)
+}
+
+func (r *roleRepository) FindByCode(ctx context.Context, code string) (*entity.Role, error) {
+	return r.findOne(ctx, roleBaseQuery+` WHERE ro.code = $1`, code)
+}
+
+func (r *roleRepository) findOne(ctx context.Context, query string, arg any) (*entity.Role, error) {
+	roles := make([]entity.Role, 1)
+	if err := r.db.DB.GetContext(ctx, &roles[0], query, arg); err != nil {
+		return nil, err
+	}
+	if err := r.loadPermissions(ctx, roles); err != nil {
+		return nil, err
+	}
+	return &roles[0], nil
+}
+
+func (r *roleRepository) FindByUser(ctx context.Context, userID uuid.UUID) ([]entity.Role, error) {
+	roles := make([]entity.Role, 0)
+	query := roleBaseQuery + ` JOIN su_user_roles ur ON ur.role_id = ro.id WHERE ur.user_id = $1 ORDER BY ro.code`
+	if err := r.db.DB.SelectContext(ctx, &roles, query, userID); err != nil {
+		return nil, fmt.Errorf("select user roles: %w", err)
+	}
+	if err := r.loadPermissions(ctx, roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// loadPermissions fills the permissions of the roles with a single query
+func (r *roleRepository) loadPermissions(ctx context.Context, roles []entity.Role) error {
+	if len(roles) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(roles))
+	byID := make(map[uuid.UUID]*entity.Role, len(roles))
+	for i := range roles {
+		roles[i].Permissions = make([]enum.PermissionEnum, 0)
+		ids[i] = roles[i].ID.String()
+		byID[roles[i].ID] = &roles[i]
+	}
+
+	var grants []entity.RolePermission
+	query := `SELECT role_id, permission_code FROM su_role_permissions WHERE role_id = ANY($1::uuid[]) ORDER BY permission_code`
+	if err := r.db.DB.SelectContext(ctx, &grants, query, ids); err != nil {
+		return fmt.Errorf("select role permissions: %w", err)
+	}
+	for _, grant := range grants {
+		role := byID[grant.RoleID]
+		role.Permissions = append(role.Permissions, grant.Permission)
+	}
+	return nil
+}
+
+func (r *roleRepository) Create(ctx context.Context, role *entity.Role) error {
+	tx, err := r.db.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO su_roles (code, name, description, is_active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`
+	if err := tx.QueryRowxContext(ctx, query,
+		role.Code,
+		role.Name,
+		role.Description,
+		role.IsActive,
+	).Scan(&role.ID, &role.CreatedAt, &role.UpdatedAt); err != nil {
+		return fmt.Errorf("insert role: %w", err)
+	}
+
+	if err := insertPermissions(ctx, tx, role); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *roleRepository) Update(ctx context.Context, role *entity.Role) error {
+	tx, err := r.db.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE su_roles
+		SET code = $1, name = $2, description = $3, is_active = $4
+		WHERE id = $5
+		RETURNING updated_at`
+	if err := tx.QueryRowxContext(ctx, query,
+		role.Code,
+		role.Name,
+		role.Description,
+		role.IsActive,
+		role.ID,
+	).Scan(&role.UpdatedAt); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM su_role_permissions WHERE role_id = $1`, role.ID); err != nil {
+		return fmt.Errorf("clear role permissions: %w", err)
+	}
+	if err := insertPermissions(ctx, tx, role); err != nil {
+		return err
+	}
+
+	holders, err := roleHolders(ctx, tx, role.ID)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	r.invalidatePermissions(holders...)
+	return nil
+}
+
+func (r *roleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	holders, err := roleHolders(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM su_roles WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete role: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	r.invalidatePermissions(holders...)
+	return nil
+}
+
+func insertPermissions(ctx context.Context, tx *sqlx.Tx, role *entity.Role) error {
+	if len(role.Permissions) == 0 {
+		return nil
+	}
+
+	codes := make([]string, len(role.Permissions))
+	for i, permission := range role.Permissions {
+		codes[i] = permission.ToString()
+	}
+	query := `
+		INSERT INTO su_role_permissions (role_id, permission_code)
+		SELECT $1, UNNEST($2::varchar[])
+		ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, role.ID, codes); err != nil {
+		return fmt.Errorf("insert role permissions: %w", err)
+	}
+	return nil
+}
+
+// roleHolders lists the users whose cached permissions a change of the role invalidates
+func roleHolders(ctx context.Context, tx *sqlx.Tx, roleID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	if err := tx.SelectContext(ctx, &userIDs, `SELECT user_id FROM su_user_roles WHERE role_id = $1`, roleID); err != nil {
+		return nil, fmt.Errorf("select role holders: %w", err)
+	}
+	return userIDs, nil
+}
+
+func (r *roleRepository) Assign(ctx context.Context, userID, roleID uuid.UUID, assignedBy *uuid.UUID) error {
+	query := `
+		INSERT INTO su_user_roles (user_id, role_id, assigned_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, role_id) DO NOTHING`
+	if _, err := r.db.DB.ExecContext(ctx, query, userID, roleID, assignedBy); err != nil {
+		return fmt.Errorf("assign role: %w", err)
+	}
+	r.invalidatePermissions(userID)
+	return nil
+}
+
+func (r *roleRepository) Unassign(ctx context.Context, userID, roleID uuid.UUID) error {
+	result, err := r.db.DB.ExecContext(ctx, `DELETE FROM su_user_roles WHERE user_id = $1 AND role_id = $2`, userID, roleID)
+	if err != nil {
+		return fmt.Errorf("unassign role: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	r.invalidatePermissions(userID)
+	return nil
+}
+
+func (r *roleRepository) FindUserPermissions(ctx context.Context, userID uuid.UUID) ([]enum.PermissionEnum, error) {
+	if permissions, ok := r.cachedPermissions(userID); ok {
+		return permissions, nil
+	}
+
+	permissions := make([]enum.PermissionEnum, 0)
+	query := `
+		SELECT DISTINCT rp.permission_code
+		FROM su_user_roles ur
+		JOIN su_roles ro ON ro.id = ur.role_id AND ro.is_active
+		JOIN su_role_permissions rp ON rp.role_id = ro.id
+		WHERE ur.user_id = $1
+		ORDER BY rp.permission_code`
+	if err := r.db.DB.SelectContext(ctx, &permissions, query, userID); err != nil {
+		return nil, fmt.Errorf("select user permissions: %w", err)
+	}
+
+	r.cachePermissions(userID, permissions)
+	return permissions, nil
+}
+
+func permissionCacheKey(userID uuid.UUID) string {
+	return "rbac:permissions:" + userID.String()
+}
+
+// cachedPermissions treats every cache failure as a miss, the database stays the source of truth
+func (r *roleRepository) cachedPermissions(userID uuid.UUID) ([]enum.PermissionEnum, bool) {
+	if r.cache == nil {
+		return nil, false
+	}
+
+	raw, err := r.cache.Get(permissionCacheKey(userID))
+	if err != nil {
+		logger.Log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to read permissions cache")
+		return nil, false
+	}
+	if raw == "" {
+		return nil, false
+	}
+
+	var permissions []enum.PermissionEnum
+	if err := json.Unmarshal([]byte(raw), &permissions); err != nil {
+		logger.Log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to decode permissions cache")
+		return nil, false
+	}
+	return permissions, true
+}
+
+func (r *roleRepository) cachePermissions(userID uuid.UUID, permissions []enum.PermissionEnum) {
+	if r.cache == nil {
+		return
+	}
+	if err := r.cache.Set(permissionCacheKey(userID), permissions, permissionCacheTTL); err != nil {
+		logger.Log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to cache permissions")
+	}
+}
+
+// invalidatePermissions drops the cached permissions of users whose roles changed
+func (r *roleRepository) invalidatePermissions(userIDs ...uuid.UUID) {
+	if r.cache == nil {
+		return
+	}
+	for _, userID := range userIDs {
+		if err := r.cache.Del(permissionCacheKey(userID)); err != nil {
+			logger.Log.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to invalidate permissions cache")
+		}
+	}
+}
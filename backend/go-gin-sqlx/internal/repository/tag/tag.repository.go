@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+const (
+	tagColumns = `t.id, t.name, t.description, t.created_by, t.created_at, t.updated_at,
+		(SELECT COUNT(*) FROM su_question_tags qt WHERE qt.tag_id = t.id) AS usage_count`
+
+	tagBaseQuery  = `SELECT ` + tagColumns + ` FROM su_tags t`
+	tagCountQuery = `SELECT COUNT(*) FROM su_tags t`
+)
+
+type ITagRepository interface {
+	FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Tag], error)
+	FindByName(ctx context.Context, name string) (*entity.Tag, error)
+	FindSynonyms(ctx context.Context, tagID uuid.UUID) ([]string, error)
+	// ResolveSynonyms maps each name that is a synonym to its canonical tag name
+	ResolveSynonyms(ctx context.Context, names []string) (map[string]string, error)
+	SynonymExists(ctx context.Context, name string) (bool, error)
+	Create(ctx context.Context, tag *entity.Tag) error
+	UpdateDescription(ctx context.Context, tag *entity.Tag) error
+	CreateSynonym(ctx context.Context, synonym *entity.TagSynonym) error
+	// Merge retags the questions of from with into, moves the synonyms of from
+	// over, deletes from and keeps its name as a synonym of into
+	Merge(ctx context.Context, from, into *entity.Tag) error
+}
+
+type tagRepository struct {
+	db *database.Database
+}
+
+func NewTagRepository(db *database.Database) ITagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) FindAll(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Tag], error) {
+	return pagination.FetchPaginated[entity.Tag](ctx, r.db.DB, tagBaseQuery, tagCountQuery, p)
+}
+
+func (r *tagRepository) FindByName(ctx context.Context, name string) (*entity.Tag, error) {
+	var tag entity.Tag
+	if err := r.db.DB.GetContext(ctx, &tag, tagBaseQuery+` WHERE t.name = $1`, name); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) FindSynonyms(ctx context.Context, tagID uuid.UUID) ([]string, error) {
+	synonyms := make([]string, 0)
+	query := `SELECT name FROM su_tag_synonyms WHERE tag_id = $1 ORDER BY name`
+	if err := r.db.DB.SelectContext(ctx, &synonyms, query, tagID); err != nil {
+		return nil, fmt.Errorf("select synonyms: %w", err)
+	}
+	return synonyms, nil
+}
+
+func (r *tagRepository) ResolveSynonyms(ctx context.Context, names []string) (map[string]string, error) {
+	resolved := make(map[string]string)
+	if len(names) == 0 {
+		return resolved, nil
+	}
+
+	var rows []struct {
+		Synonym   string `db:"synonym"`
+		Canonical string `db:"canonical"`
+	}
+	query := `
+		SELECT s.name AS synonym, t.name AS canonical
+		FROM su_tag_synonyms s
+		JOIN su_tags t ON t.id = s.tag_id
+		WHERE s.name IN (?)`
+	if err := database.SelectInContext(ctx, r.db.DB, &rows, query, names); err != nil {
+		return nil, fmt.Errorf("resolve synonyms: %w", err)
+	}
+
+	for _, row := range rows {
+		resolved[row.Synonym] = row.Canonical
+	}
+	return resolved, nil
+}
+
+func (r *tagRepository) SynonymExists(ctx context.Context, name string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM su_tag_synonyms WHERE name = $1)`
+	if err := r.db.DB.GetContext(ctx, &exists, query, name); err != nil {
+		return false, fmt.Errorf("check synonym: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *entity.Tag) error {
+	query := `
+		INSERT INTO su_tags (name, description, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at`
+
+	if err := r.db.DB.QueryRowxContext(ctx, query, tag.Name, tag.Description, tag.CreatedBy).
+		Scan(&tag.ID, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
+		return fmt.Errorf("insert tag: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepository) UpdateDescription(ctx context.Context, tag *entity.Tag) error {
+	query := `UPDATE su_tags SET description = $1 WHERE id = $2 RETURNING updated_at`
+	if err := r.db.DB.QueryRowxContext(ctx, query, tag.Description, tag.ID).Scan(&tag.UpdatedAt); err != nil {
+		return fmt.Errorf("update tag: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepository) CreateSynonym(ctx context.Context, synonym *entity.TagSynonym) error {
+	query := `INSERT INTO su_tag_synonyms (name, tag_id) VALUES ($1, $2) RETURNING created_at`
+	if err := r.db.DB.QueryRowxContext(ctx, query, synonym.Name, synonym.TagID).Scan(&synonym.CreatedAt); err != nil {
+		return fmt.Errorf("insert synonym: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepository) Merge(ctx context.Context, from, into *entity.Tag) error {
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO su_question_tags (question_id, tag_id)
+		SELECT question_id, $2 FROM su_question_tags WHERE tag_id = $1
+		ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, query, from.ID, into.ID); err != nil {
+		return fmt.Errorf("retag questions: %w", err)
+	}
+
+	// Renames the tag in place, a question that already had both keeps one
+	query = `
+		UPDATE su_questions q SET tags = (
+			SELECT array_agg(s.tag ORDER BY s.position)
+			FROM (
+				SELECT CASE WHEN t.tag = $1 THEN $2 ELSE t.tag END AS tag, MIN(t.position) AS position
+				FROM unnest(q.tags) WITH ORDINALITY AS t(tag, position)
+				GROUP BY 1
+			) s
+		)
+		WHERE $1 = ANY(q.tags)`
+	if _, err := tx.ExecContext(ctx, query, from.Name, into.Name); err != nil {
+		return fmt.Errorf("rename question tags: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE su_tag_synonyms SET tag_id = $1 WHERE tag_id = $2`, into.ID, from.ID); err != nil {
+		return fmt.Errorf("move synonyms: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM su_tags WHERE id = $1`, from.ID); err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO su_tag_synonyms (name, tag_id) VALUES ($1, $2)`, from.Name, into.ID); err != nil {
+		return fmt.Errorf("insert synonym: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+
+	"github.com/google/uuid"
+)
+
+const refreshTokenColumns = `jti, family_id, user_id, replaced_by, used_at, revoked_at, expires_at, created_at`
+
+type ITokenRepository interface {
+	Revoke(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	// RevokeUser revokes every token of the user issued up to before, an
+	// earlier instant never replaces a later one
+	RevokeUser(ctx context.Context, userID uuid.UUID, before time.Time) error
+	// RevokedBefore returns the instant set by RevokeUser, nil when there is none
+	RevokedBefore(ctx context.Context, userID uuid.UUID) (*time.Time, error)
+	CreateRefresh(ctx context.Context, token *entity.RefreshToken) error
+	FindRefresh(ctx context.Context, jti string) (*entity.RefreshToken, error)
+	// RotateRefresh marks the token as used and adds next to its family. The
+	// token is returned as it was before, unchanged when it was already used or
+	// revoked so the caller can tell a replay. It returns sql.ErrNoRows for an unknown jti.
+	RotateRefresh(ctx context.Context, jti string, next *entity.RefreshToken) (*entity.RefreshToken, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID) error
+}
+
+type tokenRepository struct {
+	db *database.Database
+}
+
+func NewTokenRepository(db *database.Database) ITokenRepository {
+	return &tokenRepository{db: db}
+}
+
+func (r *tokenRepository) Revoke(ctx context.Context, jti string, userID uuid.UUID, expiresAt time.Time) error {
+	query := `
+		INSERT INTO su_revoked_tokens (jti, user_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING`
+
+	if _, err := r.db.DB.ExecContext(ctx, query, jti, userID, expiresAt); err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+	return nil
+}
+
+func (r *tokenRepository) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	query := `SELECT EXISTS (SELECT 1 FROM su_revoked_tokens WHERE jti = $1)`
+	if err := r.db.DB.GetContext(ctx, &revoked, query, jti); err != nil {
+		return false, fmt.Errorf("check revoked token: %w", err)
+	}
+	return revoked, nil
+}
+
+func (r *tokenRepository) RevokeUser(ctx context.Context, userID uuid.UUID, before time.Time) error {
+	query := `
+		UPDATE su_users
+		SET tokens_revoked_before = GREATEST(tokens_revoked_before, $1)
+		WHERE id = $2`
+
+	if _, err := r.db.DB.ExecContext(ctx, query, before, userID); err != nil {
+		return fmt.Errorf("revoke user tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *tokenRepository) RevokedBefore(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	var before *time.Time
+	if err := r.db.DB.GetContext(ctx, &before, `SELECT tokens_revoked_before FROM su_users WHERE id = $1`, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find token watermark: %w", err)
+	}
+	return before, nil
+}
+
+func (r *tokenRepository) CreateRefresh(ctx context.Context, token *entity.RefreshToken) error {
+	query := `
+		INSERT INTO su_refresh_tokens (jti, family_id, user_id, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at`
+
+	if err := r.db.DB.QueryRowxContext(ctx, query, token.JTI, token.FamilyID, token.UserID, token.ExpiresAt).Scan(&token.CreatedAt); err != nil {
+		return fmt.Errorf("insert refresh token: %w", err)
+	}
+	return nil
+}
+
+func (r *tokenRepository) FindRefresh(ctx context.Context, jti string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	query := `SELECT ` + refreshTokenColumns + ` FROM su_refresh_tokens WHERE jti = $1`
+	if err := r.db.DB.GetContext(ctx, &token, query, jti); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *tokenRepository) RotateRefresh(ctx context.Context, jti string, next *entity.RefreshToken) (*entity.RefreshToken, error) {
+	tx, err := r.db.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the token so two concurrent refreshes with it rotate it once and the
+	// second one is seen as a replay
+	var current entity.RefreshToken
+	lock := `SELECT ` + refreshTokenColumns + ` FROM su_refresh_tokens WHERE jti = $1 FOR UPDATE`
+	if err := tx.GetContext(ctx, &current, lock, jti); err != nil {
+		return nil, err
+	}
+	if current.UsedAt != nil || current.RevokedAt != nil {
+		return &current, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE su_refresh_tokens SET used_at = NOW(), replaced_by = $1 WHERE jti = $2`, next.JTI, jti); err != nil {
+		return nil, fmt.Errorf("mark refresh token used: %w", err)
+	}
+
+	next.FamilyID = current.FamilyID
+	query := `
+		INSERT INTO su_refresh_tokens (jti, family_id, user_id, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at`
+	if err := tx.QueryRowxContext(ctx, query, next.JTI, next.FamilyID, next.UserID, next.ExpiresAt).Scan(&next.CreatedAt); err != nil {
+		return nil, fmt.Errorf("insert refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &current, nil
+}
+
+func (r *tokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `UPDATE su_refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL`
+	if _, err := r.db.DB.ExecContext(ctx, query, familyID); err != nil {
+		return fmt.Errorf("revoke refresh token family: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+const (
+	userColumns = `id, username, password, bio, avatar_url, reputation, banned_at, created_at, updated_at`
+
+	activityColumns = `act.post_type, act.post_id, act.question_id, act.question_title, act.user_id, act.body, act.score, act.created_at`
+
+	activityBaseQuery  = `SELECT ` + activityColumns + ` FROM su_user_activity act`
+	activityCountQuery = `SELECT COUNT(*) FROM su_user_activity act`
+)
+
+// renameTables hold a denormalized copy of the author's username
+var renameTables = []string{"su_questions", "su_answers", "su_comments"}
+
+type IUserRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	FindByUsername(ctx context.Context, username string) (*entity.User, error)
+	// FindByUsernames matches case-insensitively and skips unknown names
+	FindByUsernames(ctx context.Context, usernames []string) ([]entity.User, error)
+	ExistsByUsername(ctx context.Context, username string) (bool, error)
+	Create(ctx context.Context, user *entity.User) error
+	// UpdateProfile saves username, bio and avatar. A rename is copied to the
+	// posts of the user in the same transaction.
+	UpdateProfile(ctx context.Context, user *entity.User, renamed bool) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, hash string) error
+	// SetBanned bans or lifts the ban of the user, a repeated ban keeps its time
+	SetBanned(ctx context.Context, id uuid.UUID, banned bool) error
+	FindActivity(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Activity], error)
+}
+
+type userRepository struct {
+	db *database.Database
+}
+
+func NewUserRepository(db *database.Database) IUserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.DB.GetContext(ctx, &user, `SELECT `+userColumns+` FROM su_users WHERE id = $1`, id); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.DB.GetContext(ctx, &user, `SELECT `+userColumns+` FROM su_users WHERE LOWER(username) = LOWER($1)`, username); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByUsernames(ctx context.Context, usernames []string) ([]entity.User, error) {
+	lowered := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		lowered = append(lowered, strings.ToLower(username))
+	}
+
+	users := make([]entity.User, 0, len(usernames))
+	query := `SELECT ` + userColumns + ` FROM su_users WHERE LOWER(username) = ANY($1)`
+	if err := r.db.DB.SelectContext(ctx, &users, query, lowered); err != nil {
+		return nil, fmt.Errorf("select users by username: %w", err)
+	}
+	return users, nil
+}
+
+func (r *userRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM su_users WHERE LOWER(username) = LOWER($1))`
+	if err := r.db.DB.GetContext(ctx, &exists, query, username); err != nil {
+		return false, fmt.Errorf("check username: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
+	query := `
+		INSERT INTO su_users (username, password)
+		VALUES ($1, $2)
+		RETURNING id, reputation, created_at, updated_at`
+
+	if err := r.db.DB.QueryRowxContext(ctx, query, user.Username, user.Password).
+		Scan(&user.ID, &user.Reputation, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		return fmt.Errorf("insert user: %w", err)
+	}
+	return nil
+}
+
+func (r *userRepository) UpdateProfile(ctx context.Context, user *entity.User, renamed bool) error {
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE su_users SET username = $1, bio = $2, avatar_url = $3
+		WHERE id = $4
+		RETURNING updated_at`
+	if err := tx.QueryRowxContext(ctx, query, user.Username, user.Bio, user.AvatarURL, user.ID).Scan(&user.UpdatedAt); err != nil {
+		return err
+	}
+
+	// Audit trails (revisions, close votes, flags, notifications) keep the name used at the time
+	if renamed {
+		for _, table := range renameTables {
+			query := `UPDATE ` + table + ` SET username = $1 WHERE user_id = $2 AND username <> $1`
+			if _, err := tx.ExecContext(ctx, query, user.Username, user.ID); err != nil {
+				return fmt.Errorf("rename in %s: %w", table, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id uuid.UUID, hash string) error {
+	result, err := r.db.DB.ExecContext(ctx, `UPDATE su_users SET password = $1 WHERE id = $2`, hash, id)
+	if err != nil {
+		return fmt.Errorf("update password: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *userRepository) SetBanned(ctx context.Context, id uuid.UUID, banned bool) error {
+	query := `
+		UPDATE su_users SET banned_at = CASE WHEN $1 THEN COALESCE(banned_at, CURRENT_TIMESTAMP) END
+		WHERE id = $2`
+	result, err := r.db.DB.ExecContext(ctx, query, banned, id)
+	if err != nil {
+		return fmt.Errorf("set banned: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *userRepository) FindActivity(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[entity.Activity], error) {
+	return pagination.FetchPaginated[entity.Activity](ctx, r.db.DB, activityBaseQuery, activityCountQuery, p)
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// targetTables maps a vote target to the table holding its denormalized score
+var targetTables = map[enum.VoteTargetEnum]string{
+	enum.VOTE_TARGET_QUESTION: "su_questions",
+	enum.VOTE_TARGET_ANSWER:   "su_answers",
+}
+
+// deletedPostTargets lists the vote targets going away with a post, a question
+// takes its answers along
+var deletedPostTargets = map[enum.VoteTargetEnum]string{
+	enum.VOTE_TARGET_QUESTION: `
+		SELECT 'question' AS target_type, id, user_id FROM su_questions WHERE id = $1
+		UNION ALL
+		SELECT 'answer', id, user_id FROM su_answers WHERE question_id = $1`,
+	enum.VOTE_TARGET_ANSWER: `SELECT 'answer' AS target_type, id, user_id FROM su_answers WHERE id = $1`,
+}
+
+// RemovedVote is a vote deleted along with its post and the author of that post
+type RemovedVote struct {
+	entity.Vote
+	OwnerID uuid.UUID `db:"owner_id"`
+}
+
+// CastHook runs inside the vote transaction with the voter's previous value (0 when none)
+type CastHook func(ctx context.Context, tx *sqlx.Tx, previous int) error
+
+type IVoteRepository interface {
+	// Cast stores value (+1, -1, or 0 to retract) and returns the target's new score
+	Cast(ctx context.Context, userID uuid.UUID, target enum.VoteTargetEnum, targetID uuid.UUID, value int, hook CastHook) (int, error)
+	// DeleteForPost removes the votes on a post about to be deleted inside the
+	// caller's transaction, target_id has no foreign key to cascade them
+	DeleteForPost(ctx context.Context, tx *sqlx.Tx, target enum.VoteTargetEnum, postID uuid.UUID) ([]RemovedVote, error)
+}
+
+type voteRepository struct {
+	db *database.Database
+}
+
+func NewVoteRepository(db *database.Database) IVoteRepository {
+	return &voteRepository{db: db}
+}
+
+func (r *voteRepository) Cast(ctx context.Context, userID uuid.UUID, target enum.VoteTargetEnum, targetID uuid.UUID, value int, hook CastHook) (int, error) {
+	table, ok := targetTables[target]
+	if !ok {
+		return 0, fmt.Errorf("unknown vote target %q", target)
+	}
+
+	tx, err := r.db.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Locking the target serializes votes on it. The vote row cannot be locked
+	// on a first vote, two of them would both read no previous value.
+	var locked uuid.UUID
+	query := fmt.Sprintf(`SELECT id FROM %s WHERE id = $1 FOR UPDATE`, table)
+	if err := tx.GetContext(ctx, &locked, query, targetID); err != nil {
+		return 0, fmt.Errorf("lock vote target: %w", err)
+	}
+
+	var previous int
+	query = `SELECT value FROM su_votes WHERE user_id = $1 AND target_type = $2 AND target_id = $3`
+	if err := tx.GetContext(ctx, &previous, query, userID, target, targetID); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("select vote: %w", err)
+	}
+
+	if value == 0 {
+		query = `DELETE FROM su_votes WHERE user_id = $1 AND target_type = $2 AND target_id = $3`
+		if _, err := tx.ExecContext(ctx, query, userID, target, targetID); err != nil {
+			return 0, fmt.Errorf("delete vote: %w", err)
+		}
+	} else {
+		query = `
+			INSERT INTO su_votes (user_id, target_type, target_id, value)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, target_type, target_id) DO UPDATE SET value = EXCLUDED.value`
+		if _, err := tx.ExecContext(ctx, query, userID, target, targetID, value); err != nil {
+			return 0, fmt.Errorf("upsert vote: %w", err)
+		}
+	}
+
+	var score int
+	query = fmt.Sprintf(`UPDATE %s SET score = score + $1 WHERE id = $2 RETURNING score`, table)
+	if err := tx.QueryRowxContext(ctx, query, value-previous, targetID).Scan(&score); err != nil {
+		return 0, fmt.Errorf("update score: %w", err)
+	}
+
+	if hook != nil {
+		if err := hook(ctx, tx, previous); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit vote: %w", err)
+	}
+	return score, nil
+}
+
+func (r *voteRepository) DeleteForPost(ctx context.Context, tx *sqlx.Tx, target enum.VoteTargetEnum, postID uuid.UUID) ([]RemovedVote, error) {
+	targets, ok := deletedPostTargets[target]
+	if !ok {
+		return nil, fmt.Errorf("unknown vote target %q", target)
+	}
+
+	query := `
+		WITH targets AS (` + targets + `)
+		DELETE FROM su_votes v USING targets t
+		WHERE v.target_type = t.target_type AND v.target_id = t.id
+		RETURNING v.user_id, v.target_type, v.target_id, v.value, v.created_at, v.updated_at, t.user_id AS owner_id`
+	votes := make([]RemovedVote, 0)
+	if err := tx.SelectContext(ctx, &votes, query, postID); err != nil {
+		return nil, fmt.Errorf("delete post votes: %w", err)
+	}
+	return votes, nil
+}
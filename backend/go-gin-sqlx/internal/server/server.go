@@ -0,0 +1,346 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"api-stack-underflow/internal/config"
+	answerHandler "api-stack-underflow/internal/handler/answer"
+	authHandler "api-stack-underflow/internal/handler/auth"
+	badgeHandler "api-stack-underflow/internal/handler/badge"
+	bountyHandler "api-stack-underflow/internal/handler/bounty"
+	closeVoteHandler "api-stack-underflow/internal/handler/close_vote"
+	commentHandler "api-stack-underflow/internal/handler/comment"
+	draftHandler "api-stack-underflow/internal/handler/draft"
+	moderationHandler "api-stack-underflow/internal/handler/moderation"
+	notificationHandler "api-stack-underflow/internal/handler/notification"
+	questionHandler "api-stack-underflow/internal/handler/question"
+	reputationHandler "api-stack-underflow/internal/handler/reputation"
+	revisionHandler "api-stack-underflow/internal/handler/revision"
+	roleHandler "api-stack-underflow/internal/handler/role"
+	streamHandler "api-stack-underflow/internal/handler/stream"
+	tagHandler "api-stack-underflow/internal/handler/tag"
+	userHandler "api-stack-underflow/internal/handler/user"
+	voteHandler "api-stack-underflow/internal/handler/vote"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/rabbitmq"
+	"api-stack-underflow/internal/pkg/redis"
+	answerRepository "api-stack-underflow/internal/repository/answer"
+	badgeRepository "api-stack-underflow/internal/repository/badge"
+	bountyRepository "api-stack-underflow/internal/repository/bounty"
+	closeVoteRepository "api-stack-underflow/internal/repository/close_vote"
+	commentRepository "api-stack-underflow/internal/repository/comment"
+	draftRepository "api-stack-underflow/internal/repository/draft"
+	moderationRepository "api-stack-underflow/internal/repository/moderation"
+	notificationRepository "api-stack-underflow/internal/repository/notification"
+	questionRepository "api-stack-underflow/internal/repository/question"
+	reputationRepository "api-stack-underflow/internal/repository/reputation"
+	revisionRepository "api-stack-underflow/internal/repository/revision"
+	roleRepository "api-stack-underflow/internal/repository/role"
+	tagRepository "api-stack-underflow/internal/repository/tag"
+	tokenRepository "api-stack-underflow/internal/repository/token"
+	userRepository "api-stack-underflow/internal/repository/user"
+	voteRepository "api-stack-underflow/internal/repository/vote"
+	answerService "api-stack-underflow/internal/service/answer"
+	authService "api-stack-underflow/internal/service/auth"
+	badgeService "api-stack-underflow/internal/service/badge"
+	bountyService "api-stack-underflow/internal/service/bounty"
+	closeVoteService "api-stack-underflow/internal/service/close_vote"
+	commentService "api-stack-underflow/internal/service/comment"
+	draftService "api-stack-underflow/internal/service/draft"
+	moderationService "api-stack-underflow/internal/service/moderation"
+	notificationService "api-stack-underflow/internal/service/notification"
+	questionService "api-stack-underflow/internal/service/question"
+	reputationService "api-stack-underflow/internal/service/reputation"
+	revisionService "api-stack-underflow/internal/service/revision"
+	revocationService "api-stack-underflow/internal/service/revocation"
+	roleService "api-stack-underflow/internal/service/role"
+	streamService "api-stack-underflow/internal/service/stream"
+	tagService "api-stack-underflow/internal/service/tag"
+	userService "api-stack-underflow/internal/service/user"
+	viewService "api-stack-underflow/internal/service/view"
+	voteService "api-stack-underflow/internal/service/vote"
+
+	"github.com/gin-gonic/gin"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Setup wires repositories, services and handlers and mounts them on the engine
+func Setup(engine *gin.Engine, ctx context.Context, wg *sync.WaitGroup, db *database.Database) {
+	setupProxies(engine)
+	api := engine.Group("/api")
+
+	cache := setupCache(ctx, wg)
+	queue := setupQueue(ctx, wg)
+
+	// Repositories
+	userRepo := userRepository.NewUserRepository(db)
+	tokenRepo := tokenRepository.NewTokenRepository(db)
+	questionRepo := questionRepository.NewQuestionRepository(db, cache)
+	commentRepo := commentRepository.NewCommentRepository(db)
+	answerRepo := answerRepository.NewAnswerRepository(db)
+	voteRepo := voteRepository.NewVoteRepository(db)
+	tagRepo := tagRepository.NewTagRepository(db)
+	reputationRepo := reputationRepository.NewReputationRepository(db)
+	revisionRepo := revisionRepository.NewRevisionRepository(db)
+	closeVoteRepo := closeVoteRepository.NewCloseVoteRepository(db)
+	moderationRepo := moderationRepository.NewModerationRepository(db)
+	notificationRepo := notificationRepository.NewNotificationRepository(db)
+	badgeRepo := badgeRepository.NewBadgeRepository(db)
+	bountyRepo := bountyRepository.NewBountyRepository(db)
+	roleRepo := roleRepository.NewRoleRepository(db, cache)
+	// Drafts live only in Redis
+	var draftRepo draftRepository.IDraftRepository
+	if cache != nil {
+		draftRepo = draftRepository.NewDraftRepository(cache)
+	}
+
+	auth := setupAuth()
+	revocationSvc := revocationService.NewRevocationService(tokenRepo, cache, auth.MaxTTL())
+	auth.WithRevocationStore(revocationSvc)
+
+	// Services
+	authSvc := authService.NewAuthService(userRepo, tokenRepo, auth, revocationSvc)
+	userSvc := userService.NewUserService(userRepo, auth)
+	roleSvc := roleService.NewRoleService(roleRepo, userRepo, revocationSvc)
+	reputationSvc := reputationService.NewReputationService(reputationRepo, userRepo, roleRepo, voteRepo)
+	notificationSvc := notificationService.NewNotificationService(notificationRepo, questionRepo, userRepo, newPublisher(ctx, wg, queue))
+	revisionSvc := revisionService.NewRevisionService(revisionRepo, questionRepo, answerRepo, commentRepo, reputationSvc)
+	tagSvc := tagService.NewTagService(tagRepo, reputationSvc)
+	streamSvc := streamService.NewStreamService(questionRepo, cache)
+	viewSvc := viewService.NewViewService(questionRepo, cache)
+	badgeSvc := badgeService.NewBadgeService(badgeRepo, userRepo, reputationSvc, newPublisher(ctx, wg, queue))
+	questionSvc := questionService.NewQuestionService(questionRepo, commentRepo, tagSvc, reputationSvc, revisionSvc, notificationSvc, streamSvc, badgeSvc, viewSvc)
+	commentSvc := commentService.NewCommentService(commentRepo, questionRepo, revisionSvc, notificationSvc, streamSvc, badgeSvc)
+	answerSvc := answerService.NewAnswerService(answerRepo, questionRepo, reputationSvc, revisionSvc, notificationSvc, streamSvc, badgeSvc)
+	voteSvc := voteService.NewVoteService(voteRepo, questionRepo, answerRepo, reputationSvc, streamSvc, badgeSvc)
+	closeVoteSvc := closeVoteService.NewCloseVoteService(closeVoteRepo, questionRepo, reputationSvc, notificationSvc, streamSvc)
+	moderationSvc := moderationService.NewModerationService(moderationRepo, reputationSvc)
+	bountySvc := bountyService.NewBountyService(bountyRepo, questionRepo, answerRepo, reputationSvc)
+	draftSvc := draftService.NewDraftService(draftRepo, questionSvc, answerSvc)
+
+	// Handlers
+	authHandler.NewHandler(authSvc, auth).NewRoutes(api)
+	userHandler.NewHandler(userSvc, auth).NewRoutes(api)
+	questionHandler.NewHandler(questionSvc, auth).NewRoutes(api)
+	commentHandler.NewHandler(commentSvc, auth).NewRoutes(api)
+	answerHandler.NewHandler(answerSvc, auth).NewRoutes(api)
+	voteHandler.NewHandler(voteSvc, auth).NewRoutes(api)
+	tagHandler.NewHandler(tagSvc, auth).NewRoutes(api)
+	reputationHandler.NewHandler(reputationSvc, auth).NewRoutes(api)
+	revisionHandler.NewHandler(revisionSvc, auth).NewRoutes(api)
+	closeVoteHandler.NewHandler(closeVoteSvc, auth).NewRoutes(api)
+	moderationHandler.NewHandler(moderationSvc, auth).NewRoutes(api)
+	notificationHandler.NewHandler(notificationSvc, auth).NewRoutes(api)
+	streamHandler.NewHandler(streamSvc, auth).NewRoutes(api)
+	badgeHandler.NewHandler(badgeSvc, auth).NewRoutes(api)
+	bountyHandler.NewHandler(bountySvc, auth).NewRoutes(api)
+	roleHandler.NewHandler(roleSvc, auth).NewRoutes(api)
+	draftHandler.NewHandler(draftSvc, auth).NewRoutes(api)
+
+	// Background jobs
+	runPeriodically(ctx, wg, "refresh hot questions", questionService.HotRefreshInterval, questionSvc.RefreshHot)
+	runPeriodically(ctx, wg, "flush question views", viewService.FlushInterval, viewSvc.Flush)
+	runPeriodically(ctx, wg, "expire bounties", bountyService.ExpireInterval, bountySvc.ExpireDue)
+	consume(ctx, wg, queue, notificationService.NotificationQueue, notificationSvc.Consume)
+	consume(ctx, wg, queue, badgeService.BadgeQueue, badgeSvc.Consume)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		streamSvc.Run(ctx)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		revocationSvc.Run(ctx)
+	}()
+}
+
+// setupProxies only trusts X-Forwarded-For from APP_TRUSTED_PROXIES, otherwise
+// anyone could pick the address c.ClientIP() reports, e.g. to inflate views
+func setupProxies(engine *gin.Engine) {
+	if err := engine.SetTrustedProxies(config.Config.TrustedProxies); err != nil {
+		logger.Log.Error().Err(err).Strs("proxies", config.Config.TrustedProxies).Msg("Invalid APP_TRUSTED_PROXIES")
+		panic(err)
+	}
+}
+
+// setupAuth builds the token manager from JWT_*. An HS256 setup without a secret
+// stops startup, except in development where an ephemeral secret is generated
+// and every token dies with the process.
+func setupAuth() *jwt.Manager {
+	cfg := config.Config.JWT
+	jwtConfig := jwt.Config{
+		Algorithm:      cfg.Algorithm,
+		Secret:         cfg.Secret,
+		PrivateKeyPath: cfg.PrivateKeyPath,
+		PublicKeyPath:  cfg.PublicKeyPath,
+		Issuer:         cfg.Issuer,
+		Audience:       cfg.Audience,
+		AccessTTL:      cfg.AccessTTL,
+		RefreshTTL:     cfg.RefreshTTL,
+	}
+
+	isHMAC := cfg.Algorithm == "" || strings.EqualFold(cfg.Algorithm, jwt.AlgorithmHS256)
+	if isHMAC && cfg.Secret == "" && config.Config.AppEnvironment == "development" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			logger.Log.Error().Err(err).Msg("Failed to generate jwt secret")
+			panic(err)
+		}
+		jwtConfig.Secret = hex.EncodeToString(secret)
+		logger.Log.Warn().Msg("JWT_SECRET is empty, using an ephemeral secret, tokens will not survive a restart")
+	}
+
+	auth, err := jwt.NewFromConfig(jwtConfig)
+	if err != nil {
+		logger.Log.Error().Err(err).Str("algorithm", cfg.Algorithm).Msg("Failed to set up jwt")
+		panic(err)
+	}
+	return auth
+}
+
+// setupCache connects to Redis when configured. Caching is optional, so a
+// missing or unreachable Redis returns nil and callers fall back to the database.
+func setupCache(ctx context.Context, wg *sync.WaitGroup) *redis.Client {
+	cfg := config.Config.Redis
+	if cfg.Host == "" {
+		logger.Log.Info().Msg("Redis not configured, running without cache")
+		return nil
+	}
+
+	client, err := redis.Setup(ctx, &redis.Config{
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+		PoolSize: cfg.PoolSize,
+	})
+	if err != nil {
+		logger.Log.Warn().Err(err).Msg("Redis unavailable, running without cache")
+		return nil
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if err := client.Close(); err != nil {
+			logger.Log.Error().Err(err).Msg("Failed to close redis client")
+		}
+	}()
+	return client
+}
+
+// setupQueue connects to RabbitMQ when configured. Like the cache it is optional,
+// nil means events are not published and nothing is consumed.
+func setupQueue(ctx context.Context, wg *sync.WaitGroup) *rabbitmq.ConnectionManager {
+	cfg := config.Config.RabbitMQ
+	if cfg.URI == "" && cfg.Host == "" {
+		logger.Log.Info().Msg("RabbitMQ not configured, running without notifications")
+		return nil
+	}
+
+	conn, err := rabbitmq.NewConnectionManager(ctx, &rabbitmq.Config{
+		URI:      cfg.URI,
+		Host:     cfg.Host,
+		Port:     cfg.Port,
+		Username: cfg.Username,
+		Password: cfg.Password,
+	})
+	if err != nil {
+		logger.Log.Warn().Err(err).Msg("RabbitMQ unavailable, running without notifications")
+		return nil
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if err := conn.Close(); err != nil {
+			logger.Log.Error().Err(err).Msg("Failed to close rabbitmq connection")
+		}
+	}()
+	return conn
+}
+
+// newPublisher returns nil without a queue connection
+func newPublisher(ctx context.Context, wg *sync.WaitGroup, conn *rabbitmq.ConnectionManager) *rabbitmq.Publisher {
+	if conn == nil {
+		return nil
+	}
+
+	publisher, err := rabbitmq.NewPublisher(ctx, conn)
+	if err != nil {
+		logger.Log.Warn().Err(err).Msg("Failed to create rabbitmq publisher, running without notifications")
+		return nil
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if err := publisher.Close(); err != nil {
+			logger.Log.Error().Err(err).Msg("Failed to close rabbitmq publisher")
+		}
+	}()
+	return publisher
+}
+
+// consume runs handle for every message of the queue until ctx is cancelled.
+// A handler error sends the message through the subscriber retry and dead letter path.
+func consume(ctx context.Context, wg *sync.WaitGroup, conn *rabbitmq.ConnectionManager, queue string, handle func(context.Context, []byte) error) {
+	if conn == nil {
+		return
+	}
+
+	subscriber, err := rabbitmq.NewSubscriber(ctx, conn, func(msg *amqp.Delivery) (interface{}, error) {
+		return nil, handle(ctx, msg.Body)
+	}, rabbitmq.DefaultSubscribeOptions(queue, false))
+	if err != nil {
+		logger.Log.Error().Err(err).Str("queue", queue).Msg("Failed to create rabbitmq subscriber")
+		return
+	}
+	if err := subscriber.Start(); err != nil {
+		logger.Log.Error().Err(err).Str("queue", queue).Msg("Failed to start rabbitmq subscriber")
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		if err := subscriber.Stop(); err != nil {
+			logger.Log.Error().Err(err).Str("queue", queue).Msg("Failed to stop rabbitmq subscriber")
+		}
+	}()
+}
+
+// runPeriodically runs job right away and then every interval until ctx is cancelled
+func runPeriodically(ctx context.Context, wg *sync.WaitGroup, name string, interval time.Duration, job func(context.Context) error) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := job(ctx); err != nil && ctx.Err() == nil {
+				logger.Log.Error().Err(err).Str("job", name).Msg("Background job failed")
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/answer"
+	questionDto "api-stack-underflow/internal/dto/question"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/pagination"
+	answerRepository "api-stack-underflow/internal/repository/answer"
+	questionRepository "api-stack-underflow/internal/repository/question"
+	badgeService "api-stack-underflow/internal/service/badge"
+	notificationService "api-stack-underflow/internal/service/notification"
+	questionService "api-stack-underflow/internal/service/question"
+	reputationService "api-stack-underflow/internal/service/reputation"
+	revisionService "api-stack-underflow/internal/service/revision"
+	streamService "api-stack-underflow/internal/service/stream"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrAnswerNotFound  = errors.New("answer not found")
+	ErrAnswerForbidden = errors.New("not allowed to modify this answer")
+	ErrAcceptForbidden = errors.New("only the question author can accept an answer")
+	ErrQuestionClosed  = errors.New("question is closed")
+)
+
+type IAnswerService interface {
+	List(ctx context.Context, questionID uuid.UUID, p *pagination.Pagination) (pagination.PaginatedResponse[dto.AnswerResponse], error)
+	Create(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.CreateAnswerRequest) (*dto.AnswerResponse, error)
+	Update(ctx context.Context, user *jwt.Claims, questionID, answerID uuid.UUID, req dto.UpdateAnswerRequest) (*dto.AnswerResponse, error)
+	Delete(ctx context.Context, user *jwt.Claims, questionID, answerID uuid.UUID) error
+	Accept(ctx context.Context, user *jwt.Claims, questionID, answerID uuid.UUID) (*dto.AnswerResponse, error)
+}
+
+type answerService struct {
+	repo            answerRepository.IAnswerRepository
+	questionRepo    questionRepository.IQuestionRepository
+	reputationSvc   reputationService.IReputationService
+	revisionSvc     revisionService.IRevisionService
+	notificationSvc notificationService.INotificationService
+	streamSvc       streamService.IStreamService
+	badgeSvc        badgeService.IBadgeService
+}
+
+func NewAnswerService(repo answerRepository.IAnswerRepository, questionRepo questionRepository.IQuestionRepository, reputationSvc reputationService.IReputationService, revisionSvc revisionService.IRevisionService, notificationSvc notificationService.INotificationService, streamSvc streamService.IStreamService, badgeSvc badgeService.IBadgeService) IAnswerService {
+	return &answerService{repo: repo, questionRepo: questionRepo, reputationSvc: reputationSvc, revisionSvc: revisionSvc, notificationSvc: notificationSvc, streamSvc: streamSvc, badgeSvc: badgeSvc}
+}
+
+// AnswerPaginationConfig scopes the answer list to one question
+func AnswerPaginationConfig(questionID uuid.UUID) pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithFilter("question_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("a")).
+		WithFilter("is_hidden", pagination.WithDataType("boolean"), pagination.WithTableAlias("a")).
+		WithSort("id", pagination.WithSortTableAlias("a")).
+		WithSort("created_at", pagination.WithSortTableAlias("a")).
+		WithSort("score", pagination.WithSortTableAlias("a")).
+		SetDefaultSort("created_at", pagination.WithSortTableAlias("a"))
+	config.DefaultFilter["question_id"] = pagination.DefaultFilterField{
+		Value:    questionID.String(),
+		Operator: "=",
+	}
+	config.DefaultFilter["is_hidden"] = pagination.DefaultFilterField{
+		Value:    "false",
+		Operator: "=",
+	}
+	return config
+}
+
+func (s *answerService) List(ctx context.Context, questionID uuid.UUID, p *pagination.Pagination) (pagination.PaginatedResponse[dto.AnswerResponse], error) {
+	if _, err := s.findQuestion(ctx, questionID); err != nil {
+		return pagination.PaginatedResponse[dto.AnswerResponse]{}, err
+	}
+
+	// The path already scopes the list, a query string question_id must not widen it
+	delete(p.Filters, "question_id")
+	// Hidden answers only surface through the moderation queue
+	delete(p.Filters, "is_hidden")
+
+	result, err := s.repo.FindAll(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.AnswerResponse]{}, fmt.Errorf("list answers: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewAnswerResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+func (s *answerService) Create(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.CreateAnswerRequest) (*dto.AnswerResponse, error) {
+	question, err := s.findQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.Status == enum.QUESTION_CLOSED {
+		return nil, ErrQuestionClosed
+	}
+
+	answer := &entity.Answer{
+		QuestionID: questionID,
+		UserID:     user.UserID,
+		Username:   user.Username,
+		Content:    req.Content,
+	}
+	if err := s.repo.Create(ctx, answer); err != nil {
+		return nil, fmt.Errorf("create answer: %w", err)
+	}
+	s.notificationSvc.Notify(ctx, entity.NotificationEvent{
+		Event:         enum.NOTIFICATION_EVENT_ANSWER_CREATED,
+		QuestionID:    questionID,
+		PostType:      enum.POST_TYPE_ANSWER,
+		PostID:        answer.ID,
+		ActorID:       user.UserID,
+		ActorUsername: user.Username,
+	})
+	s.badgeSvc.Publish(ctx, entity.DomainEvent{
+		Event:    enum.DOMAIN_EVENT_ANSWER_CREATED,
+		ActorID:  user.UserID,
+		PostType: enum.POST_TYPE_ANSWER,
+		PostID:   answer.ID,
+	})
+	response := dto.NewAnswerResponse(*answer)
+	s.streamSvc.Publish(ctx, questionID, enum.QUESTION_EVENT_ANSWER_CREATED, response)
+	return &response, nil
+}
+
+func (s *answerService) Update(ctx context.Context, user *jwt.Claims, questionID, answerID uuid.UUID, req dto.UpdateAnswerRequest) (*dto.AnswerResponse, error) {
+	answer, err := s.find(ctx, questionID, answerID)
+	if err != nil {
+		return nil, err
+	}
+	if answer.UserID != user.UserID {
+		if err := s.reputationSvc.Require(ctx, user.UserID, enum.PRIVILEGE_EDIT_OTHERS); err != nil {
+			if errors.Is(err, reputationService.ErrInsufficientReputation) {
+				return nil, ErrAnswerForbidden
+			}
+			return nil, err
+		}
+	}
+
+	post := revisionService.RevisionPost{Type: enum.POST_TYPE_ANSWER, ID: answer.ID, QuestionID: answer.QuestionID}
+	answer, err = s.repo.Update(ctx, answerID, func(ctx context.Context, tx *sqlx.Tx, answer *entity.Answer) error {
+		before := *answer
+		answer.Content = req.Content
+		_, err := s.revisionSvc.Record(ctx, tx, user, post, before, *answer, "")
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update answer: %w", err)
+	}
+	response := dto.NewAnswerResponse(*answer)
+	return &response, nil
+}
+
+func (s *answerService) Delete(ctx context.Context, user *jwt.Claims, questionID, answerID uuid.UUID) error {
+	answer, err := s.find(ctx, questionID, answerID)
+	if err != nil {
+		return err
+	}
+	if answer.UserID != user.UserID {
+		return ErrAnswerForbidden
+	}
+	question, err := s.findQuestion(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	err = s.repo.Delete(ctx, answer, func(ctx context.Context, tx *sqlx.Tx, accepted *entity.Answer) error {
+		return s.reputationSvc.RevokePost(ctx, tx, enum.VOTE_TARGET_ANSWER, answer.ID, accepted, question.UserID)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAnswerNotFound
+		}
+		return fmt.Errorf("delete answer: %w", err)
+	}
+	if answer.IsAccepted {
+		// Deleting the accepted answer reopens the question
+		s.publishStatus(ctx, questionID)
+	}
+	return nil
+}
+
+func (s *answerService) Accept(ctx context.Context, user *jwt.Claims, questionID, answerID uuid.UUID) (*dto.AnswerResponse, error) {
+	question, err := s.findQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.UserID != user.UserID {
+		return nil, ErrAcceptForbidden
+	}
+	if question.Status == enum.QUESTION_CLOSED {
+		return nil, ErrQuestionClosed
+	}
+
+	answer, err := s.find(ctx, questionID, answerID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reputation for the new and any previously accepted answer moves with the accept itself
+	hook := func(ctx context.Context, tx *sqlx.Tx, previous *entity.Answer) error {
+		return s.reputationSvc.Apply(ctx, tx, reputationService.AcceptEvents(answer, previous, question.UserID))
+	}
+
+	if err := s.repo.Accept(ctx, questionID, answerID, hook); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAnswerNotFound
+		}
+		if errors.Is(err, answerRepository.ErrQuestionClosed) {
+			return nil, ErrQuestionClosed
+		}
+		return nil, fmt.Errorf("accept answer: %w", err)
+	}
+
+	answer.IsAccepted = true
+	s.badgeSvc.Publish(ctx, entity.DomainEvent{
+		Event:    enum.DOMAIN_EVENT_ANSWER_ACCEPTED,
+		ActorID:  user.UserID,
+		OwnerID:  &answer.UserID,
+		PostType: enum.POST_TYPE_ANSWER,
+		PostID:   answer.ID,
+	})
+	response := dto.NewAnswerResponse(*answer)
+	s.streamSvc.Publish(ctx, questionID, enum.QUESTION_EVENT_ANSWER_ACCEPTED, response)
+	if question.Status != enum.QUESTION_ANSWERED {
+		s.publishStatus(ctx, questionID)
+	}
+	return &response, nil
+}
+
+// publishStatus reloads the question so subscribers see the status the repository settled on
+func (s *answerService) publishStatus(ctx context.Context, questionID uuid.UUID) {
+	question, err := s.questionRepo.FindByID(ctx, questionID)
+	if err != nil {
+		logger.Log.Warn().Err(err).Str("question_id", questionID.String()).Msg("Failed to reload question for status event")
+		return
+	}
+	s.streamSvc.Publish(ctx, questionID, enum.QUESTION_EVENT_STATUS_CHANGED, questionDto.NewQuestionResponse(*question))
+}
+
+func (s *answerService) find(ctx context.Context, questionID, answerID uuid.UUID) (*entity.Answer, error) {
+	answer, err := s.repo.FindByID(ctx, answerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAnswerNotFound
+		}
+		return nil, fmt.Errorf("find answer: %w", err)
+	}
+	if answer.QuestionID != questionID {
+		return nil, ErrAnswerNotFound
+	}
+	return answer, nil
+}
+
+func (s *answerService) findQuestion(ctx context.Context, id uuid.UUID) (*entity.Question, error) {
+	question, err := s.questionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, questionService.ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("find question: %w", err)
+	}
+	return question, nil
+}
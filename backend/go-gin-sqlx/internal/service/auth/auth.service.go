@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	dto "api-stack-underflow/internal/dto/auth"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/validation"
+	tokenRepository "api-stack-underflow/internal/repository/token"
+	userRepository "api-stack-underflow/internal/repository/user"
+	revocationService "api-stack-underflow/internal/service/revocation"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid username or password")
+	ErrUsernameTaken       = errors.New("username is already taken")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, sign in again")
+	ErrWrongPassword       = errors.New("current password is incorrect")
+	ErrUserBanned          = errors.New("user is banned")
+)
+
+type IAuthService interface {
+	Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error)
+	Signup(ctx context.Context, req dto.SignupRequest) (*dto.AuthResponse, error)
+	// RefreshToken rotates the refresh token, the presented one cannot be used
+	// again. Replaying a rotated token revokes every token of its login.
+	RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (*dto.AuthResponse, error)
+	// Logout revokes the access token and the login of the refresh token, if any
+	Logout(ctx context.Context, access *jwt.Claims, req dto.LogoutRequest) error
+	// LogoutAll revokes every token of the user on every device
+	LogoutAll(ctx context.Context, userID uuid.UUID) error
+	// ChangePassword replaces the password and revokes every token of the user,
+	// the client signs in again with the new one
+	ChangePassword(ctx context.Context, userID uuid.UUID, req dto.ChangePasswordRequest) error
+	Me(ctx context.Context, userID uuid.UUID) (*dto.UserResponse, error)
+}
+
+type authService struct {
+	userRepo      userRepository.IUserRepository
+	tokenRepo     tokenRepository.ITokenRepository
+	auth          *jwt.Manager
+	revocationSvc revocationService.IRevocationService
+}
+
+func NewAuthService(userRepo userRepository.IUserRepository, tokenRepo tokenRepository.ITokenRepository, auth *jwt.Manager, revocationSvc revocationService.IRevocationService) IAuthService {
+	return &authService{userRepo: userRepo, tokenRepo: tokenRepo, auth: auth, revocationSvc: revocationSvc}
+}
+
+func (s *authService) Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error) {
+	user, err := s.userRepo.FindByUsername(ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	if user.BannedAt != nil {
+		return nil, ErrUserBanned
+	}
+	return s.issueTokens(ctx, user)
+}
+
+func (s *authService) Signup(ctx context.Context, req dto.SignupRequest) (*dto.AuthResponse, error) {
+	if err := validation.Unique(ctx, req.Username, s.userRepo.ExistsByUsername); err != nil {
+		if errors.Is(err, validation.ErrNotUnique) {
+			return nil, ErrUsernameTaken
+		}
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("hash password: %w", err)
+	}
+
+	user := &entity.User{
+		Username: req.Username,
+		Password: string(hash),
+	}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		// A concurrent signup with the same name took it after the check above
+		if database.IsUniqueViolation(err) {
+			return nil, ErrUsernameTaken
+		}
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+	return s.issueTokens(ctx, user)
+}
+
+func (s *authService) RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (*dto.AuthResponse, error) {
+	claims, err := s.auth.Verify(ctx, req.RefreshToken, jwt.RefreshToken)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if user.BannedAt != nil {
+		return nil, ErrUserBanned
+	}
+
+	refresh, refreshClaims, err := s.auth.GenerateToken(user.ID, user.Username, jwt.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.tokenRepo.RotateRefresh(ctx, claims.ID, &entity.RefreshToken{
+		JTI:       refreshClaims.ID,
+		UserID:    user.ID,
+		ExpiresAt: refreshClaims.ExpiresAt.Time,
+	})
+	if err != nil {
+		// Tokens issued before rotation existed have no row and need a new login
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("rotate refresh token: %w", err)
+	}
+	if previous.RevokedAt != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if previous.UsedAt != nil {
+		// Either the client or a thief holds a stolen copy, the whole login goes
+		if err := s.tokenRepo.RevokeFamily(ctx, previous.FamilyID); err != nil {
+			return nil, err
+		}
+		logger.Log.Warn().
+			Str("user_id", user.ID.String()).
+			Str("family_id", previous.FamilyID.String()).
+			Msg("Refresh token replayed, token family revoked")
+		return nil, ErrRefreshTokenReused
+	}
+
+	access, accessClaims, err := s.auth.GenerateToken(user.ID, user.Username, jwt.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AuthResponse{
+		UserResponse: dto.UserResponse{ID: user.ID, Username: user.Username},
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresAt:    accessClaims.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (s *authService) Logout(ctx context.Context, access *jwt.Claims, req dto.LogoutRequest) error {
+	if err := s.revocationSvc.RevokeToken(ctx, access); err != nil {
+		return err
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+	refresh, err := s.auth.Parse(req.RefreshToken, jwt.RefreshToken)
+	if err != nil || refresh.UserID != access.UserID {
+		return ErrInvalidRefreshToken
+	}
+	if err := s.revocationSvc.RevokeToken(ctx, refresh); err != nil {
+		return err
+	}
+
+	token, err := s.tokenRepo.FindRefresh(ctx, refresh.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("find refresh token: %w", err)
+	}
+	return s.tokenRepo.RevokeFamily(ctx, token.FamilyID)
+}
+
+func (s *authService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	return s.revocationSvc.RevokeUser(ctx, userID)
+}
+
+func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, req dto.ChangePasswordRequest) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("find user: %w", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return ErrWrongPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+	if err := s.userRepo.UpdatePassword(ctx, userID, string(hash)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return s.revocationSvc.RevokeUser(ctx, userID)
+}
+
+func (s *authService) Me(ctx context.Context, userID uuid.UUID) (*dto.UserResponse, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	return &dto.UserResponse{ID: user.ID, Username: user.Username}, nil
+}
+
+// issueTokens starts a new refresh token family for a login or signup
+func (s *authService) issueTokens(ctx context.Context, user *entity.User) (*dto.AuthResponse, error) {
+	access, accessClaims, err := s.auth.GenerateToken(user.ID, user.Username, jwt.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	refresh, refreshClaims, err := s.auth.GenerateToken(user.ID, user.Username, jwt.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokenRepo.CreateRefresh(ctx, &entity.RefreshToken{
+		JTI:       refreshClaims.ID,
+		FamilyID:  uuid.New(),
+		UserID:    user.ID,
+		ExpiresAt: refreshClaims.ExpiresAt.Time,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthResponse{
+		UserResponse: dto.UserResponse{ID: user.ID, Username: user.Username},
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresAt:    accessClaims.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/badge"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/rabbitmq"
+	badgeRepository "api-stack-underflow/internal/repository/badge"
+	userRepository "api-stack-underflow/internal/repository/user"
+	reputationService "api-stack-underflow/internal/service/reputation"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// BadgeQueue carries DomainEvent messages to the badge consumer
+	BadgeQueue = "badges"
+
+	badgePattern = "domain.event"
+	// publishTimeout bounds how long a request waits on the broker
+	publishTimeout = 5 * time.Second
+)
+
+var (
+	ErrUserNotFound   = errors.New("user not found")
+	ErrBadgeForbidden = errors.New("not allowed to backfill badges")
+)
+
+// Rule declares a badge: what it is, which events can earn it and the
+// criteria checked against the current data. Rules only look at stored state,
+// so evaluating one again, live or as a backfill, never awards twice.
+type Rule struct {
+	Badge       enum.BadgeEnum
+	Name        string
+	Tier        enum.BadgeTierEnum
+	Description string
+	// PostType is set for badges earned once per post
+	PostType *enum.PostTypeEnum
+	Events   []enum.DomainEventEnum
+	Criteria badgeRepository.Criteria
+}
+
+var (
+	questionPost = enum.POST_TYPE_QUESTION
+	answerPost   = enum.POST_TYPE_ANSWER
+)
+
+// Rules is the badge catalog in display order
+var Rules = []Rule{
+	{
+		Badge: enum.BADGE_STUDENT, Name: "Student", Tier: enum.BADGE_TIER_BRONZE,
+		Description: "Asked a first question",
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_QUESTION_CREATED},
+		Criteria:    badgeRepository.Criteria{Table: "su_questions", Condition: "NOT is_hidden", MinCount: 1},
+	},
+	{
+		Badge: enum.BADGE_TEACHER, Name: "Teacher", Tier: enum.BADGE_TIER_BRONZE,
+		Description: "Answered a first question",
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_ANSWER_CREATED},
+		Criteria:    badgeRepository.Criteria{Table: "su_answers", Condition: "NOT is_hidden", MinCount: 1},
+	},
+	{
+		Badge: enum.BADGE_SCHOLAR, Name: "Scholar", Tier: enum.BADGE_TIER_BRONZE,
+		Description: "Had an answer accepted",
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_ANSWER_ACCEPTED},
+		Criteria:    badgeRepository.Criteria{Table: "su_answers", Condition: "is_accepted AND NOT is_hidden", MinCount: 1},
+	},
+	{
+		Badge: enum.BADGE_COMMENTATOR, Name: "Commentator", Tier: enum.BADGE_TIER_BRONZE,
+		Description: "Left 10 comments",
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_COMMENT_CREATED},
+		Criteria:    badgeRepository.Criteria{Table: "su_comments", Condition: "NOT is_hidden", MinCount: 10},
+	},
+	{
+		Badge: enum.BADGE_SUPPORTER, Name: "Supporter", Tier: enum.BADGE_TIER_BRONZE,
+		Description: "Cast a first upvote",
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_VOTE_CAST},
+		Criteria:    badgeRepository.Criteria{Table: "su_votes", Condition: "value = 1", MinCount: 1},
+	},
+	{
+		Badge: enum.BADGE_CRITIC, Name: "Critic", Tier: enum.BADGE_TIER_BRONZE,
+		Description: "Cast a first downvote",
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_VOTE_CAST},
+		Criteria:    badgeRepository.Criteria{Table: "su_votes", Condition: "value = -1", MinCount: 1},
+	},
+	{
+		Badge: enum.BADGE_NICE_QUESTION, Name: "Nice Question", Tier: enum.BADGE_TIER_BRONZE,
+		Description: "Question score of 10 or more",
+		PostType:    &questionPost,
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_VOTE_CAST},
+		Criteria:    badgeRepository.Criteria{Table: "su_questions", Condition: "score >= 10 AND NOT is_hidden", PerPost: true},
+	},
+	{
+		Badge: enum.BADGE_NICE_ANSWER, Name: "Nice Answer", Tier: enum.BADGE_TIER_BRONZE,
+		Description: "Answer score of 10 or more",
+		PostType:    &answerPost,
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_VOTE_CAST},
+		Criteria:    badgeRepository.Criteria{Table: "su_answers", Condition: "score >= 10 AND NOT is_hidden", PerPost: true},
+	},
+	{
+		Badge: enum.BADGE_GOOD_ANSWER, Name: "Good Answer", Tier: enum.BADGE_TIER_SILVER,
+		Description: "Answer score of 25 or more",
+		PostType:    &answerPost,
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_VOTE_CAST},
+		Criteria:    badgeRepository.Criteria{Table: "su_answers", Condition: "score >= 25 AND NOT is_hidden", PerPost: true},
+	},
+	{
+		Badge: enum.BADGE_GREAT_ANSWER, Name: "Great Answer", Tier: enum.BADGE_TIER_GOLD,
+		Description: "Answer score of 100 or more",
+		PostType:    &answerPost,
+		Events:      []enum.DomainEventEnum{enum.DOMAIN_EVENT_VOTE_CAST},
+		Criteria:    badgeRepository.Criteria{Table: "su_answers", Condition: "score >= 100 AND NOT is_hidden", PerPost: true},
+	},
+}
+
+type IBadgeService interface {
+	// Publish queues the event for the badge consumer. Like notifications it
+	// never fails the caller; a missed event is picked up by the next backfill.
+	Publish(ctx context.Context, event entity.DomainEvent)
+	// Consume evaluates the rules listening to a queued event for the users it touched
+	Consume(ctx context.Context, body []byte) error
+	// Backfill evaluates every rule against all existing data
+	Backfill(ctx context.Context, user *jwt.Claims) (*dto.BackfillResponse, error)
+	Catalog(ctx context.Context) ([]dto.BadgeResponse, error)
+	UserBadges(ctx context.Context, username string) ([]dto.UserBadgeResponse, error)
+}
+
+type badgeService struct {
+	repo          badgeRepository.IBadgeRepository
+	userRepo      userRepository.IUserRepository
+	reputationSvc reputationService.IReputationService
+	publisher     *rabbitmq.Publisher
+}
+
+// NewBadgeService takes an optional publisher, nil leaves badges to the backfill
+func NewBadgeService(repo badgeRepository.IBadgeRepository, userRepo userRepository.IUserRepository, reputationSvc reputationService.IReputationService, publisher *rabbitmq.Publisher) IBadgeService {
+	return &badgeService{repo: repo, userRepo: userRepo, reputationSvc: reputationSvc, publisher: publisher}
+}
+
+func (s *badgeService) Publish(ctx context.Context, event entity.DomainEvent) {
+	if s.publisher == nil {
+		return
+	}
+
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	msg, err := rabbitmq.NewMessage(event, nil)
+	if err != nil {
+		logger.Log.Warn().Err(err).Str("event", event.Event.ToString()).Msg("Failed to build domain event message")
+		return
+	}
+
+	opts := rabbitmq.DefaultPublishOptions(BadgeQueue, badgePattern, false)
+	opts.MaxRetries = 1
+	opts.RetryBackoff = time.Second
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	if _, err := s.publisher.PublishWithContext(ctx, msg, opts); err != nil {
+		logger.Log.Warn().Err(err).Str("event", event.Event.ToString()).Str("post_id", event.PostID.String()).Msg("Failed to publish domain event")
+	}
+}
+
+func (s *badgeService) Consume(ctx context.Context, body []byte) error {
+	var message struct {
+		Pattern string             `json:"type"`
+		Data    entity.DomainEvent `json:"data"`
+	}
+	if err := json.Unmarshal(body, &message); err != nil {
+		return fmt.Errorf("decode domain event: %w", err)
+	}
+	if message.Pattern != badgePattern || !message.Data.Event.IsValid() {
+		logger.Log.Warn().Str("pattern", message.Pattern).Str("event", message.Data.Event.ToString()).Msg("Skipping unknown domain event")
+		return nil
+	}
+
+	event := message.Data
+	for _, rule := range Rules {
+		if !slices.Contains(rule.Events, event.Event) {
+			continue
+		}
+		if _, err := s.award(ctx, rule, event.UserIDs()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *badgeService) Backfill(ctx context.Context, user *jwt.Claims) (*dto.BackfillResponse, error) {
+	if err := s.reputationSvc.Require(ctx, user.UserID, enum.PRIVILEGE_MODERATE); err != nil {
+		if errors.Is(err, reputationService.ErrInsufficientReputation) {
+			return nil, fmt.Errorf("%w: %w", ErrBadgeForbidden, err)
+		}
+		return nil, err
+	}
+
+	response := &dto.BackfillResponse{ByBadge: make(map[enum.BadgeEnum]int)}
+	for _, rule := range Rules {
+		awarded, err := s.award(ctx, rule, nil)
+		if err != nil {
+			return nil, err
+		}
+		response.ByBadge[rule.Badge] = awarded
+		response.Awarded += awarded
+	}
+	return response, nil
+}
+
+func (s *badgeService) Catalog(ctx context.Context) ([]dto.BadgeResponse, error) {
+	counts, err := s.repo.CountAwarded(ctx)
+	if err != nil {
+		return nil, err
+	}
+	awarded := make(map[enum.BadgeEnum]int, len(counts))
+	for _, c := range counts {
+		awarded[c.Badge] = c.Count
+	}
+
+	responses := make([]dto.BadgeResponse, 0, len(Rules))
+	for _, rule := range Rules {
+		responses = append(responses, dto.BadgeResponse{
+			Badge:       rule.Badge,
+			Name:        rule.Name,
+			Tier:        rule.Tier,
+			Description: rule.Description,
+			Awarded:     awarded[rule.Badge],
+		})
+	}
+	return responses, nil
+}
+
+func (s *badgeService) UserBadges(ctx context.Context, username string) ([]dto.UserBadgeResponse, error) {
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	badges, err := s.repo.FindByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.UserBadgeResponse, 0, len(badges))
+	for _, b := range badges {
+		rule, ok := findRule(b.Badge)
+		if !ok {
+			// Retired from the catalog, the award stays stored but is not shown
+			continue
+		}
+		responses = append(responses, dto.UserBadgeResponse{
+			Badge:     b.Badge,
+			Name:      rule.Name,
+			Tier:      rule.Tier,
+			PostType:  rule.PostType,
+			PostID:    b.PostID,
+			AwardedAt: b.AwardedAt.Format(time.RFC3339),
+		})
+	}
+	return responses, nil
+}
+
+// award evaluates one rule, userIDs nil means every user
+func (s *badgeService) award(ctx context.Context, rule Rule, userIDs []uuid.UUID) (int, error) {
+	awarded, err := s.repo.Award(ctx, rule.Badge, rule.Criteria, userIDs)
+	if err != nil {
+		return 0, err
+	}
+	for _, b := range awarded {
+		logger.Log.Info().Str("badge", b.Badge.ToString()).Str("user_id", b.UserID.String()).Msg("Badge awarded")
+	}
+	return len(awarded), nil
+}
+
+func findRule(badge enum.BadgeEnum) (Rule, bool) {
+	for _, rule := range Rules {
+		if rule.Badge == badge {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
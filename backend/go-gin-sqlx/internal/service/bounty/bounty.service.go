@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/bounty"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	answerRepository "api-stack-underflow/internal/repository/answer"
+	bountyRepository "api-stack-underflow/internal/repository/bounty"
+	questionRepository "api-stack-underflow/internal/repository/question"
+	answerService "api-stack-underflow/internal/service/answer"
+	questionService "api-stack-underflow/internal/service/question"
+	reputationService "api-stack-underflow/internal/service/reputation"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	DefaultBountyDays = 7
+	// AutoAwardMinScore is the score an answer posted during the bounty needs
+	// to receive it when the sponsor lets it expire
+	AutoAwardMinScore = 2
+	// ExpireInterval is how often expired bounties are awarded or refunded
+	ExpireInterval = 5 * time.Minute
+
+	expireBatchSize = 100
+)
+
+var (
+	ErrQuestionClosed    = errors.New("question is closed")
+	ErrBountyActive      = errors.New("question already has an active bounty")
+	ErrNoActiveBounty    = errors.New("question has no active bounty")
+	ErrBountyForbidden   = errors.New("only the sponsor can award the bounty")
+	ErrSelfAward         = errors.New("cannot award a bounty to your own answer")
+	ErrBountyUnavailable = errors.New("question is no longer open for a bounty")
+)
+
+type IBountyService interface {
+	List(ctx context.Context, questionID uuid.UUID) ([]dto.BountyResponse, error)
+	// Start takes the amount from the sponsor's reputation right away
+	Start(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.StartBountyRequest) (*dto.BountyResponse, error)
+	Award(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.AwardBountyRequest) (*dto.BountyResponse, error)
+	// ExpireDue awards every expired bounty to the best answer posted while it
+	// ran, or refunds the sponsor when no answer reached AutoAwardMinScore
+	ExpireDue(ctx context.Context) error
+}
+
+type bountyService struct {
+	repo          bountyRepository.IBountyRepository
+	questionRepo  questionRepository.IQuestionRepository
+	answerRepo    answerRepository.IAnswerRepository
+	reputationSvc reputationService.IReputationService
+}
+
+func NewBountyService(repo bountyRepository.IBountyRepository, questionRepo questionRepository.IQuestionRepository, answerRepo answerRepository.IAnswerRepository, reputationSvc reputationService.IReputationService) IBountyService {
+	return &bountyService{repo: repo, questionRepo: questionRepo, answerRepo: answerRepo, reputationSvc: reputationSvc}
+}
+
+func (s *bountyService) List(ctx context.Context, questionID uuid.UUID) ([]dto.BountyResponse, error) {
+	if _, err := s.findQuestion(ctx, questionID); err != nil {
+		return nil, err
+	}
+
+	bounties, err := s.repo.FindByQuestion(ctx, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("list bounties: %w", err)
+	}
+	return dto.NewBountyResponses(bounties), nil
+}
+
+func (s *bountyService) Start(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.StartBountyRequest) (*dto.BountyResponse, error) {
+	question, err := s.findQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.Status == enum.QUESTION_CLOSED {
+		return nil, ErrQuestionClosed
+	}
+	if question.BountyAmount > 0 {
+		return nil, ErrBountyActive
+	}
+
+	days := req.DurationDays
+	if days == 0 {
+		days = DefaultBountyDays
+	}
+	bounty := &entity.Bounty{
+		QuestionID: questionID,
+		UserID:     user.UserID,
+		Amount:     req.Amount,
+		ExpiresAt:  time.Now().AddDate(0, 0, days),
+	}
+
+	// The sponsor's reputation is checked under lock so it never goes negative
+	hook := func(ctx context.Context, tx *sqlx.Tx, reputation int) error {
+		if reputation < bounty.Amount {
+			return fmt.Errorf("%w: a bounty of %d needs as much reputation, you have %d", reputationService.ErrInsufficientReputation, bounty.Amount, reputation)
+		}
+		return s.reputationSvc.Apply(ctx, tx, []entity.ReputationEvent{{
+			UserID:   user.UserID,
+			Amount:   -bounty.Amount,
+			Reason:   enum.REPUTATION_BOUNTY_OFFERED,
+			PostType: enum.VOTE_TARGET_QUESTION,
+			PostID:   questionID,
+			ActorID:  &user.UserID,
+		}})
+	}
+
+	if err := s.repo.Start(ctx, bounty, hook); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBountyUnavailable
+		}
+		if errors.Is(err, reputationService.ErrInsufficientReputation) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("start bounty: %w", err)
+	}
+
+	response := dto.NewBountyResponse(*bounty)
+	return &response, nil
+}
+
+func (s *bountyService) Award(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.AwardBountyRequest) (*dto.BountyResponse, error) {
+	if _, err := s.findQuestion(ctx, questionID); err != nil {
+		return nil, err
+	}
+
+	active, err := s.repo.FindActive(ctx, questionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoActiveBounty
+		}
+		return nil, fmt.Errorf("find active bounty: %w", err)
+	}
+	if active.UserID != user.UserID {
+		return nil, ErrBountyForbidden
+	}
+
+	answer, err := s.answerRepo.FindByID(ctx, req.AnswerID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("find answer: %w", err)
+	}
+	if answer == nil || answer.QuestionID != questionID {
+		return nil, answerService.ErrAnswerNotFound
+	}
+	if answer.UserID == user.UserID {
+		return nil, ErrSelfAward
+	}
+
+	bounty, err := s.award(ctx, active.ID, answer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoActiveBounty
+		}
+		return nil, fmt.Errorf("award bounty: %w", err)
+	}
+
+	response := dto.NewBountyResponse(*bounty)
+	return &response, nil
+}
+
+func (s *bountyService) ExpireDue(ctx context.Context) error {
+	for {
+		bounties, err := s.repo.FindExpired(ctx, expireBatchSize)
+		if err != nil {
+			return fmt.Errorf("find expired bounties: %w", err)
+		}
+
+		for _, bounty := range bounties {
+			if err := s.expire(ctx, &bounty); err != nil {
+				return err
+			}
+		}
+		if len(bounties) < expireBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *bountyService) expire(ctx context.Context, bounty *entity.Bounty) error {
+	answer, err := s.repo.FindAutoAwardAnswer(ctx, bounty, AutoAwardMinScore)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("find auto award answer: %w", err)
+	}
+
+	if answer != nil {
+		_, err = s.award(ctx, bounty.ID, answer)
+	} else {
+		_, err = s.refund(ctx, bounty.ID)
+	}
+	// Awarded by the sponsor since the bounty was listed
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("expire bounty %s: %w", bounty.ID, err)
+	}
+
+	logger.Log.Info().
+		Str("bounty_id", bounty.ID.String()).
+		Bool("awarded", answer != nil).
+		Msg("Expired bounty resolved")
+	return nil
+}
+
+func (s *bountyService) award(ctx context.Context, bountyID uuid.UUID, answer *entity.Answer) (*entity.Bounty, error) {
+	hook := func(ctx context.Context, tx *sqlx.Tx, bounty *entity.Bounty) error {
+		return s.reputationSvc.Apply(ctx, tx, []entity.ReputationEvent{{
+			UserID:   answer.UserID,
+			Amount:   bounty.Amount,
+			Reason:   enum.REPUTATION_BOUNTY_AWARDED,
+			PostType: enum.VOTE_TARGET_ANSWER,
+			PostID:   answer.ID,
+			ActorID:  &bounty.UserID,
+		}})
+	}
+	return s.repo.Resolve(ctx, bountyID, enum.BOUNTY_AWARDED, &answer.ID, hook)
+}
+
+func (s *bountyService) refund(ctx context.Context, bountyID uuid.UUID) (*entity.Bounty, error) {
+	hook := func(ctx context.Context, tx *sqlx.Tx, bounty *entity.Bounty) error {
+		return s.reputationSvc.Apply(ctx, tx, []entity.ReputationEvent{{
+			UserID:   bounty.UserID,
+			Amount:   bounty.Amount,
+			Reason:   enum.REPUTATION_BOUNTY_REFUNDED,
+			PostType: enum.VOTE_TARGET_QUESTION,
+			PostID:   bounty.QuestionID,
+		}})
+	}
+	return s.repo.Resolve(ctx, bountyID, enum.BOUNTY_REFUNDED, nil, hook)
+}
+
+func (s *bountyService) findQuestion(ctx context.Context, id uuid.UUID) (*entity.Question, error) {
+	question, err := s.questionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, questionService.ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("find question: %w", err)
+	}
+	if question.IsHidden {
+		return nil, questionService.ErrQuestionNotFound
+	}
+	return question, nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/config"
+	dto "api-stack-underflow/internal/dto/close_vote"
+	questionDto "api-stack-underflow/internal/dto/question"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/pagination"
+	closeVoteRepository "api-stack-underflow/internal/repository/close_vote"
+	questionRepository "api-stack-underflow/internal/repository/question"
+	notificationService "api-stack-underflow/internal/service/notification"
+	questionService "api-stack-underflow/internal/service/question"
+	reputationService "api-stack-underflow/internal/service/reputation"
+	streamService "api-stack-underflow/internal/service/stream"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCloseVoteForbidden = errors.New("not allowed to vote to close or reopen questions")
+	ErrAlreadyClosed      = errors.New("question is already closed")
+	ErrNotClosed          = errors.New("question is not closed")
+	ErrAlreadyVoted       = errors.New("already voted on this question")
+	ErrInvalidDuplicate   = errors.New("a question cannot be a duplicate of itself")
+	ErrDuplicateNotFound  = errors.New("duplicate original not found")
+)
+
+type ICloseVoteService interface {
+	List(ctx context.Context, questionID uuid.UUID, p *pagination.Pagination) (pagination.PaginatedResponse[dto.CloseVoteResponse], error)
+	Close(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.CloseQuestionRequest) (*dto.CloseVoteResultResponse, error)
+	Reopen(ctx context.Context, user *jwt.Claims, questionID uuid.UUID) (*dto.CloseVoteResultResponse, error)
+}
+
+type closeVoteService struct {
+	repo            closeVoteRepository.ICloseVoteRepository
+	questionRepo    questionRepository.IQuestionRepository
+	reputationSvc   reputationService.IReputationService
+	notificationSvc notificationService.INotificationService
+	streamSvc       streamService.IStreamService
+}
+
+func NewCloseVoteService(repo closeVoteRepository.ICloseVoteRepository, questionRepo questionRepository.IQuestionRepository, reputationSvc reputationService.IReputationService, notificationSvc notificationService.INotificationService, streamSvc streamService.IStreamService) ICloseVoteService {
+	return &closeVoteService{repo: repo, questionRepo: questionRepo, reputationSvc: reputationSvc, notificationSvc: notificationSvc, streamSvc: streamSvc}
+}
+
+// CloseVotePaginationConfig scopes the close vote audit trail to one question
+func CloseVotePaginationConfig(questionID uuid.UUID) pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithFilter("question_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("cv")).
+		WithFilter("action", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("cv")).
+		WithFilter("resolved", pagination.WithDataType("boolean"), pagination.WithTableAlias("cv")).
+		WithSort("created_at", pagination.WithSortTableAlias("cv")).
+		SetDefaultSort("created_at", pagination.WithSortTableAlias("cv"))
+	config.DefaultFilter["question_id"] = pagination.DefaultFilterField{
+		Value:    questionID.String(),
+		Operator: "=",
+	}
+	return config
+}
+
+func (s *closeVoteService) List(ctx context.Context, questionID uuid.UUID, p *pagination.Pagination) (pagination.PaginatedResponse[dto.CloseVoteResponse], error) {
+	if _, err := s.findQuestion(ctx, questionID); err != nil {
+		return pagination.PaginatedResponse[dto.CloseVoteResponse]{}, err
+	}
+
+	// The path already scopes the list, a query string question_id must not widen it
+	delete(p.Filters, "question_id")
+
+	result, err := s.repo.FindAll(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.CloseVoteResponse]{}, fmt.Errorf("list close votes: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewCloseVoteResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+func (s *closeVoteService) Close(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.CloseQuestionRequest) (*dto.CloseVoteResultResponse, error) {
+	question, err := s.findQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.Status == enum.QUESTION_CLOSED {
+		return nil, ErrAlreadyClosed
+	}
+
+	reason := req.Reason
+	vote := &entity.CloseVote{
+		QuestionID: questionID,
+		UserID:     user.UserID,
+		Username:   user.Username,
+		Action:     enum.CLOSE_VOTE_CLOSE,
+		Reason:     &reason,
+	}
+	if reason == enum.CLOSE_REASON_DUPLICATE {
+		original, err := s.resolveOriginal(ctx, questionID, req.DuplicateOf)
+		if err != nil {
+			return nil, err
+		}
+		vote.DuplicateOf = &original
+	}
+
+	return s.cast(ctx, user, vote, config.Config.Moderation.CloseVotes)
+}
+
+func (s *closeVoteService) Reopen(ctx context.Context, user *jwt.Claims, questionID uuid.UUID) (*dto.CloseVoteResultResponse, error) {
+	question, err := s.findQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question.Status != enum.QUESTION_CLOSED {
+		return nil, ErrNotClosed
+	}
+
+	vote := &entity.CloseVote{
+		QuestionID: questionID,
+		UserID:     user.UserID,
+		Username:   user.Username,
+		Action:     enum.CLOSE_VOTE_REOPEN,
+	}
+	return s.cast(ctx, user, vote, config.Config.Moderation.ReopenVotes)
+}
+
+func (s *closeVoteService) cast(ctx context.Context, user *jwt.Claims, vote *entity.CloseVote, needed int) (*dto.CloseVoteResultResponse, error) {
+	if err := s.reputationSvc.Require(ctx, user.UserID, enum.PRIVILEGE_CLOSE_QUESTION); err != nil {
+		if errors.Is(err, reputationService.ErrInsufficientReputation) {
+			return nil, fmt.Errorf("%w: %w", ErrCloseVoteForbidden, err)
+		}
+		return nil, err
+	}
+
+	voted, err := s.repo.HasPending(ctx, vote.QuestionID, user.UserID, vote.Action)
+	if err != nil {
+		return nil, err
+	}
+	if voted {
+		return nil, ErrAlreadyVoted
+	}
+
+	count, resolved, err := s.repo.Cast(ctx, vote, needed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Another vote resolved the question between the check and the cast
+			if vote.Action == enum.CLOSE_VOTE_CLOSE {
+				return nil, ErrAlreadyClosed
+			}
+			return nil, ErrNotClosed
+		}
+		return nil, fmt.Errorf("cast close vote: %w", err)
+	}
+	if resolved {
+		event := enum.NOTIFICATION_EVENT_QUESTION_CLOSED
+		if vote.Action == enum.CLOSE_VOTE_REOPEN {
+			event = enum.NOTIFICATION_EVENT_QUESTION_REOPENED
+		}
+		s.notificationSvc.Notify(ctx, entity.NotificationEvent{
+			Event:         event,
+			QuestionID:    vote.QuestionID,
+			PostType:      enum.POST_TYPE_QUESTION,
+			PostID:        vote.QuestionID,
+			ActorID:       user.UserID,
+			ActorUsername: user.Username,
+		})
+	}
+
+	question, err := s.findQuestion(ctx, vote.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	response := questionDto.NewQuestionResponse(*question)
+	if resolved {
+		s.streamSvc.Publish(ctx, vote.QuestionID, enum.QUESTION_EVENT_STATUS_CHANGED, response)
+	}
+	return &dto.CloseVoteResultResponse{
+		Question:    response,
+		Action:      vote.Action,
+		Votes:       count,
+		VotesNeeded: needed,
+		Resolved:    resolved,
+	}, nil
+}
+
+// resolveOriginal validates the duplicate target and follows it once when the
+// target is itself a closed duplicate, so links never chain
+func (s *closeVoteService) resolveOriginal(ctx context.Context, questionID uuid.UUID, duplicateOf *uuid.UUID) (uuid.UUID, error) {
+	if duplicateOf == nil || *duplicateOf == questionID {
+		return uuid.Nil, ErrInvalidDuplicate
+	}
+
+	original, err := s.questionRepo.FindByID(ctx, *duplicateOf)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrDuplicateNotFound
+		}
+		return uuid.Nil, fmt.Errorf("find duplicate original: %w", err)
+	}
+
+	target := original.ID
+	if original.DuplicateOf != nil {
+		target = *original.DuplicateOf
+	}
+	if target == questionID {
+		return uuid.Nil, ErrInvalidDuplicate
+	}
+	return target, nil
+}
+
+func (s *closeVoteService) findQuestion(ctx context.Context, id uuid.UUID) (*entity.Question, error) {
+	question, err := s.questionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, questionService.ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("find question: %w", err)
+	}
+	return question, nil
+}
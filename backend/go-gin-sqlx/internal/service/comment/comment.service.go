@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/comment"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	commentRepository "api-stack-underflow/internal/repository/comment"
+	questionRepository "api-stack-underflow/internal/repository/question"
+	badgeService "api-stack-underflow/internal/service/badge"
+	notificationService "api-stack-underflow/internal/service/notification"
+	questionService "api-stack-underflow/internal/service/question"
+	revisionService "api-stack-underflow/internal/service/revision"
+	streamService "api-stack-underflow/internal/service/stream"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrCommentForbidden = errors.New("only the author can modify this comment")
+)
+
+type ICommentService interface {
+	Create(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.CreateCommentRequest) (*dto.CommentResponse, error)
+	Update(ctx context.Context, user *jwt.Claims, questionID, commentID uuid.UUID, req dto.UpdateCommentRequest) (*dto.CommentResponse, error)
+	Delete(ctx context.Context, user *jwt.Claims, questionID, commentID uuid.UUID) error
+}
+
+type commentService struct {
+	repo            commentRepository.ICommentRepository
+	questionRepo    questionRepository.IQuestionRepository
+	revisionSvc     revisionService.IRevisionService
+	notificationSvc notificationService.INotificationService
+	streamSvc       streamService.IStreamService
+	badgeSvc        badgeService.IBadgeService
+}
+
+func NewCommentService(repo commentRepository.ICommentRepository, questionRepo questionRepository.IQuestionRepository, revisionSvc revisionService.IRevisionService, notificationSvc notificationService.INotificationService, streamSvc streamService.IStreamService, badgeSvc badgeService.IBadgeService) ICommentService {
+	return &commentService{repo: repo, questionRepo: questionRepo, revisionSvc: revisionSvc, notificationSvc: notificationSvc, streamSvc: streamSvc, badgeSvc: badgeSvc}
+}
+
+func (s *commentService) Create(ctx context.Context, user *jwt.Claims, questionID uuid.UUID, req dto.CreateCommentRequest) (*dto.CommentResponse, error) {
+	if _, err := s.questionRepo.FindByID(ctx, questionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, questionService.ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("find question: %w", err)
+	}
+
+	comment := &entity.Comment{
+		QuestionID: questionID,
+		UserID:     user.UserID,
+		Username:   user.Username,
+		Content:    req.Content,
+	}
+	if err := s.repo.Create(ctx, comment); err != nil {
+		return nil, fmt.Errorf("create comment: %w", err)
+	}
+	s.notificationSvc.Notify(ctx, entity.NotificationEvent{
+		Event:         enum.NOTIFICATION_EVENT_COMMENT_CREATED,
+		QuestionID:    questionID,
+		PostType:      enum.POST_TYPE_COMMENT,
+		PostID:        comment.ID,
+		ActorID:       user.UserID,
+		ActorUsername: user.Username,
+		Mentions:      helper.ExtractMentions(comment.Content),
+	})
+	s.badgeSvc.Publish(ctx, entity.DomainEvent{
+		Event:    enum.DOMAIN_EVENT_COMMENT_CREATED,
+		ActorID:  user.UserID,
+		PostType: enum.POST_TYPE_COMMENT,
+		PostID:   comment.ID,
+	})
+	response := dto.NewCommentResponse(*comment)
+	s.streamSvc.Publish(ctx, questionID, enum.QUESTION_EVENT_COMMENT_CREATED, response)
+	return &response, nil
+}
+
+func (s *commentService) Update(ctx context.Context, user *jwt.Claims, questionID, commentID uuid.UUID, req dto.UpdateCommentRequest) (*dto.CommentResponse, error) {
+	comment, err := s.findOwned(ctx, user, questionID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	post := revisionService.RevisionPost{Type: enum.POST_TYPE_COMMENT, ID: comment.ID, QuestionID: comment.QuestionID}
+	comment, err = s.repo.Update(ctx, commentID, func(ctx context.Context, tx *sqlx.Tx, comment *entity.Comment) error {
+		before := *comment
+		comment.Content = req.Content
+		_, err := s.revisionSvc.Record(ctx, tx, user, post, before, *comment, "")
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update comment: %w", err)
+	}
+	response := dto.NewCommentResponse(*comment)
+	return &response, nil
+}
+
+func (s *commentService) Delete(ctx context.Context, user *jwt.Claims, questionID, commentID uuid.UUID) error {
+	if _, err := s.findOwned(ctx, user, questionID, commentID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, commentID); err != nil {
+		return fmt.Errorf("delete comment: %w", err)
+	}
+	return nil
+}
+
+// findOwned loads a comment of the given question and checks that the caller wrote it
+func (s *commentService) findOwned(ctx context.Context, user *jwt.Claims, questionID, commentID uuid.UUID) (*entity.Comment, error) {
+	comment, err := s.repo.FindByID(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("find comment: %w", err)
+	}
+	if comment.QuestionID != questionID {
+		return nil, ErrCommentNotFound
+	}
+	if comment.UserID != user.UserID {
+		return nil, ErrCommentForbidden
+	}
+	return comment, nil
+}
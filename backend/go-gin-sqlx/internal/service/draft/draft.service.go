@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	answerDto "api-stack-underflow/internal/dto/answer"
+	dto "api-stack-underflow/internal/dto/draft"
+	questionDto "api-stack-underflow/internal/dto/question"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/validation"
+	draftRepository "api-stack-underflow/internal/repository/draft"
+	answerService "api-stack-underflow/internal/service/answer"
+	questionService "api-stack-underflow/internal/service/question"
+)
+
+// DraftTTL is how long a draft survives after its last save
+const DraftTTL = 7 * 24 * time.Hour
+
+var (
+	ErrDraftNotFound      = errors.New("draft not found")
+	ErrDraftsUnavailable  = errors.New("drafts are not available")
+	ErrDraftIncomplete    = errors.New("draft is not ready to submit")
+	ErrDraftTypeImmutable = errors.New("draft type cannot change, delete the draft first")
+)
+
+type IDraftService interface {
+	Get(ctx context.Context, user *jwt.Claims, key string) (*dto.DraftResponse, error)
+	// Save creates or replaces the draft and restarts its TTL
+	Save(ctx context.Context, user *jwt.Claims, key string, req dto.SaveDraftRequest) (*dto.DraftResponse, error)
+	Delete(ctx context.Context, user *jwt.Claims, key string) error
+	// Submit turns the draft into a question or answer. The draft is claimed
+	// before the post is created, so a double submit creates a single post, and
+	// put back when the post cannot be created.
+	Submit(ctx context.Context, user *jwt.Claims, key string) (*dto.SubmitDraftResponse, error)
+}
+
+type draftService struct {
+	repo        draftRepository.IDraftRepository
+	questionSvc questionService.IQuestionService
+	answerSvc   answerService.IAnswerService
+}
+
+// NewDraftService takes an optional repository, drafts need Redis and every
+// call returns ErrDraftsUnavailable without it
+func NewDraftService(repo draftRepository.IDraftRepository, questionSvc questionService.IQuestionService, answerSvc answerService.IAnswerService) IDraftService {
+	return &draftService{repo: repo, questionSvc: questionSvc, answerSvc: answerSvc}
+}
+
+func (s *draftService) Get(ctx context.Context, user *jwt.Claims, key string) (*dto.DraftResponse, error) {
+	draft, err := s.find(ctx, user, key)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.NewDraftResponse(*draft)
+	return &response, nil
+}
+
+func (s *draftService) Save(ctx context.Context, user *jwt.Claims, key string, req dto.SaveDraftRequest) (*dto.DraftResponse, error) {
+	if s.repo == nil {
+		return nil, ErrDraftsUnavailable
+	}
+
+	existing, err := s.repo.Find(ctx, user.UserID, key)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Type != req.Type {
+		return nil, ErrDraftTypeImmutable
+	}
+
+	now := time.Now()
+	draft := &entity.Draft{
+		Key:       key,
+		Type:      req.Type,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(DraftTTL),
+	}
+	switch req.Type {
+	case enum.POST_TYPE_QUESTION:
+		draft.Title = req.Title
+		draft.Description = req.Description
+		draft.Tags = req.Tags
+	case enum.POST_TYPE_ANSWER:
+		draft.QuestionID = req.QuestionID
+		draft.Content = req.Content
+	}
+
+	if err := s.repo.Save(ctx, user.UserID, draft, DraftTTL); err != nil {
+		return nil, err
+	}
+
+	response := dto.NewDraftResponse(*draft)
+	return &response, nil
+}
+
+func (s *draftService) Delete(ctx context.Context, user *jwt.Claims, key string) error {
+	if s.repo == nil {
+		return ErrDraftsUnavailable
+	}
+	return s.repo.Delete(ctx, user.UserID, key)
+}
+
+func (s *draftService) Submit(ctx context.Context, user *jwt.Claims, key string) (*dto.SubmitDraftResponse, error) {
+	if s.repo == nil {
+		return nil, ErrDraftsUnavailable
+	}
+
+	draft, err := s.repo.Take(ctx, user.UserID, key)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, ErrDraftNotFound
+	}
+
+	response, err := s.publish(ctx, user, draft)
+	if err != nil {
+		s.restore(ctx, user, draft)
+		return nil, err
+	}
+	return response, nil
+}
+
+// publish creates the post, the draft goes through the same validation as a create request
+func (s *draftService) publish(ctx context.Context, user *jwt.Claims, draft *entity.Draft) (*dto.SubmitDraftResponse, error) {
+	switch draft.Type {
+	case enum.POST_TYPE_QUESTION:
+		req := questionDto.CreateQuestionRequest{
+			Title:       draft.Title,
+			Description: draft.Description,
+			Tags:        draft.Tags,
+		}
+		if err := validation.Struct(req); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrDraftIncomplete, err)
+		}
+
+		question, err := s.questionSvc.Create(ctx, user, req)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.SubmitDraftResponse{Type: draft.Type, Question: question}, nil
+
+	case enum.POST_TYPE_ANSWER:
+		req := answerDto.CreateAnswerRequest{Content: draft.Content}
+		if err := validation.Struct(req); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrDraftIncomplete, err)
+		}
+		if draft.QuestionID == nil {
+			return nil, fmt.Errorf("%w: question_id is required", ErrDraftIncomplete)
+		}
+
+		answer, err := s.answerSvc.Create(ctx, user, *draft.QuestionID, req)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.SubmitDraftResponse{Type: draft.Type, Answer: answer}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown type %q", ErrDraftIncomplete, draft.Type)
+}
+
+// restore puts back a draft whose submit failed, keeping what is left of its TTL
+func (s *draftService) restore(ctx context.Context, user *jwt.Claims, draft *entity.Draft) {
+	ttl := time.Until(draft.ExpiresAt)
+	if ttl <= 0 {
+		return
+	}
+	if err := s.repo.Save(ctx, user.UserID, draft, ttl); err != nil {
+		logger.Log.Warn().Err(err).Str("user_id", user.UserID.String()).Str("draft", draft.Key).Msg("Failed to restore draft after a failed submit")
+	}
+}
+
+func (s *draftService) find(ctx context.Context, user *jwt.Claims, key string) (*entity.Draft, error) {
+	if s.repo == nil {
+		return nil, ErrDraftsUnavailable
+	}
+
+	draft, err := s.repo.Find(ctx, user.UserID, key)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, ErrDraftNotFound
+	}
+	return draft, nil
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/config"
+	dto "api-stack-underflow/internal/dto/moderation"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/pagination"
+	moderationRepository "api-stack-underflow/internal/repository/moderation"
+	reputationService "api-stack-underflow/internal/service/reputation"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPostNotFound        = errors.New("post not found")
+	ErrFlagNotFound        = errors.New("flag not found")
+	ErrFlagOwnPost         = errors.New("cannot flag your own post")
+	ErrFlagForbidden       = errors.New("not allowed to flag posts")
+	ErrAlreadyFlagged      = errors.New("already flagged this post")
+	ErrFlagReviewed        = errors.New("flag has already been reviewed")
+	ErrModerationForbidden = errors.New("not allowed to moderate posts")
+)
+
+type IModerationService interface {
+	Flag(ctx context.Context, user *jwt.Claims, req dto.CreateFlagRequest) (*dto.FlagResultResponse, error)
+	Queue(ctx context.Context, user *jwt.Claims, p *pagination.Pagination) (pagination.PaginatedResponse[dto.FlagResponse], error)
+	Approve(ctx context.Context, user *jwt.Claims, flagID uuid.UUID, req dto.ReviewFlagRequest) (*dto.FlagResultResponse, error)
+	Dismiss(ctx context.Context, user *jwt.Claims, flagID uuid.UUID, req dto.ReviewFlagRequest) (*dto.FlagResultResponse, error)
+	Logs(ctx context.Context, user *jwt.Claims, p *pagination.Pagination) (pagination.PaginatedResponse[dto.ModerationLogResponse], error)
+}
+
+type moderationService struct {
+	repo          moderationRepository.IModerationRepository
+	reputationSvc reputationService.IReputationService
+}
+
+func NewModerationService(repo moderationRepository.IModerationRepository, reputationSvc reputationService.IReputationService) IModerationService {
+	return &moderationService{repo: repo, reputationSvc: reputationSvc}
+}
+
+// FlagPaginationConfig describes the filters and sorts of the review queue
+func FlagPaginationConfig() pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithFilter("status", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("f")).
+		WithFilter("reason", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("f")).
+		WithFilter("post_type", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("f")).
+		WithFilter("question_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("f")).
+		WithSort("id", pagination.WithSortTableAlias("f")).
+		WithSort("created_at", pagination.WithSortTableAlias("f")).
+		SetDefaultSort("created_at", pagination.WithSortTableAlias("f"))
+	return config
+}
+
+// ModerationLogPaginationConfig describes the filters and sorts of the moderation log
+func ModerationLogPaginationConfig() pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithFilter("action", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("ml")).
+		WithFilter("post_type", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("ml")).
+		WithFilter("post_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("ml")).
+		WithFilter("moderator_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("ml")).
+		WithSort("id", pagination.WithSortTableAlias("ml")).
+		WithSort("created_at", pagination.WithSortTableAlias("ml")).
+		SetDefaultSort("created_at", pagination.WithSortTableAlias("ml"))
+	return config
+}
+
+// Flag needs the flag privilege, spam flags hide the post automatically and
+// must not come from throwaway accounts
+func (s *moderationService) Flag(ctx context.Context, user *jwt.Claims, req dto.CreateFlagRequest) (*dto.FlagResultResponse, error) {
+	if err := s.reputationSvc.Require(ctx, user.UserID, enum.PRIVILEGE_FLAG); err != nil {
+		if errors.Is(err, reputationService.ErrInsufficientReputation) {
+			return nil, fmt.Errorf("%w: %w", ErrFlagForbidden, err)
+		}
+		return nil, err
+	}
+
+	post, err := s.findPost(ctx, req.PostType, req.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if post.UserID == user.UserID {
+		return nil, ErrFlagOwnPost
+	}
+
+	flagged, err := s.repo.HasPendingFlag(ctx, post.Type, post.ID, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if flagged {
+		return nil, ErrAlreadyFlagged
+	}
+
+	flag := &entity.Flag{
+		PostType:   post.Type,
+		PostID:     post.ID,
+		QuestionID: post.QuestionID,
+		UserID:     user.UserID,
+		Username:   user.Username,
+		Reason:     req.Reason,
+		Note:       req.Note,
+	}
+	if err := s.repo.CreateFlag(ctx, flag); err != nil {
+		return nil, fmt.Errorf("create flag: %w", err)
+	}
+
+	if flag.Reason == enum.FLAG_REASON_SPAM && !post.IsHidden {
+		if err := s.autoHide(ctx, flag, post); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.FlagResultResponse{Flag: dto.NewFlagResponse(*flag), PostHidden: post.IsHidden}, nil
+}
+
+// autoHide hides a post once its open spam flags reach the configured threshold,
+// the log has no moderator and points at the flag that tipped it over
+func (s *moderationService) autoHide(ctx context.Context, flag *entity.Flag, post *entity.ModeratedPost) error {
+	count, err := s.repo.CountFlags(ctx, post.Type, post.ID, enum.FLAG_REASON_SPAM)
+	if err != nil {
+		return err
+	}
+	threshold := config.Config.Moderation.SpamFlagsToHide
+	if count < threshold {
+		return nil
+	}
+
+	hidden := *post
+	hidden.IsHidden = true
+	notes := fmt.Sprintf("hidden after %d spam flags", count)
+
+	log, err := newLog(enum.MODERATION_AUTO_HIDE, nil, flag, flag, post, &hidden, notes)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Apply(ctx, nil, &hidden, log); err != nil {
+		return fmt.Errorf("auto hide post: %w", err)
+	}
+
+	post.IsHidden = true
+	return nil
+}
+
+func (s *moderationService) Queue(ctx context.Context, user *jwt.Claims, p *pagination.Pagination) (pagination.PaginatedResponse[dto.FlagResponse], error) {
+	if err := s.require(ctx, user); err != nil {
+		return pagination.PaginatedResponse[dto.FlagResponse]{}, err
+	}
+
+	// The queue shows what still needs a decision unless a status is asked for
+	if _, ok := p.Filters["status"]; !ok {
+		p.Filters["status"] = enum.FLAG_PENDING.ToString()
+	}
+
+	result, err := s.repo.FindFlags(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.FlagResponse]{}, fmt.Errorf("list flags: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewFlagResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+// Approve agrees with the flag and hides the post
+func (s *moderationService) Approve(ctx context.Context, user *jwt.Claims, flagID uuid.UUID, req dto.ReviewFlagRequest) (*dto.FlagResultResponse, error) {
+	flag, post, err := s.findPending(ctx, user, flagID)
+	if err != nil {
+		return nil, err
+	}
+
+	after := *post
+	after.IsHidden = true
+	return s.review(ctx, user, enum.MODERATION_APPROVE, flag, post, &after, req.Note)
+}
+
+// Dismiss rejects the flag, a hidden post comes back unless another approved
+// flag or the spam threshold still keeps it hidden
+func (s *moderationService) Dismiss(ctx context.Context, user *jwt.Claims, flagID uuid.UUID, req dto.ReviewFlagRequest) (*dto.FlagResultResponse, error) {
+	flag, post, err := s.findPending(ctx, user, flagID)
+	if err != nil {
+		return nil, err
+	}
+
+	after := *post
+	if post.IsHidden {
+		visible, err := s.visibleWithout(ctx, flag, post)
+		if err != nil {
+			return nil, err
+		}
+		after.IsHidden = !visible
+	}
+	return s.review(ctx, user, enum.MODERATION_DISMISS, flag, post, &after, req.Note)
+}
+
+// visibleWithout reports whether the post would no longer be hidden once the flag is dismissed
+func (s *moderationService) visibleWithout(ctx context.Context, flag *entity.Flag, post *entity.ModeratedPost) (bool, error) {
+	approved, err := s.repo.CountApproved(ctx, post.Type, post.ID)
+	if err != nil {
+		return false, err
+	}
+	if approved > 0 {
+		return false, nil
+	}
+
+	spam, err := s.repo.CountFlags(ctx, post.Type, post.ID, enum.FLAG_REASON_SPAM)
+	if err != nil {
+		return false, err
+	}
+	if flag.Reason == enum.FLAG_REASON_SPAM {
+		spam--
+	}
+	return spam < config.Config.Moderation.SpamFlagsToHide, nil
+}
+
+func (s *moderationService) review(ctx context.Context, user *jwt.Claims, action enum.ModerationActionEnum, flag *entity.Flag, before, after *entity.ModeratedPost, note string) (*dto.FlagResultResponse, error) {
+	now := time.Now()
+	reviewed := *flag
+	reviewed.ReviewedBy = &user.UserID
+	reviewed.ReviewedAt = &now
+	reviewed.Status = enum.FLAG_APPROVED
+	if action == enum.MODERATION_DISMISS {
+		reviewed.Status = enum.FLAG_DISMISSED
+	}
+
+	log, err := newLog(action, &user.UserID, flag, &reviewed, before, after, note)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Apply(ctx, &reviewed, after, log); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFlagReviewed
+		}
+		return nil, fmt.Errorf("review flag: %w", err)
+	}
+
+	return &dto.FlagResultResponse{Flag: dto.NewFlagResponse(reviewed), PostHidden: after.IsHidden}, nil
+}
+
+func (s *moderationService) Logs(ctx context.Context, user *jwt.Claims, p *pagination.Pagination) (pagination.PaginatedResponse[dto.ModerationLogResponse], error) {
+	if err := s.require(ctx, user); err != nil {
+		return pagination.PaginatedResponse[dto.ModerationLogResponse]{}, err
+	}
+
+	result, err := s.repo.FindLogs(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.ModerationLogResponse]{}, fmt.Errorf("list moderation logs: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewModerationLogResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+// newLog records what changed on the flag and on the post, moderatorID is nil for automatic actions
+func newLog(action enum.ModerationActionEnum, moderatorID *uuid.UUID, flagBefore, flagAfter *entity.Flag, postBefore, postAfter *entity.ModeratedPost, notes string) (*entity.ModerationLog, error) {
+	changes := helper.TrackChanges(flagBefore, flagAfter)
+	for field, change := range helper.TrackChanges(postBefore, postAfter) {
+		changes[field] = change
+	}
+
+	payload, err := helper.BuildHistoryPayload(changes, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ModerationLog{
+		Action:      action,
+		PostType:    postBefore.Type,
+		PostID:      postBefore.ID,
+		FlagID:      &flagBefore.ID,
+		ModeratorID: moderatorID,
+		Payload:     payload,
+	}, nil
+}
+
+func (s *moderationService) findPending(ctx context.Context, user *jwt.Claims, flagID uuid.UUID) (*entity.Flag, *entity.ModeratedPost, error) {
+	if err := s.require(ctx, user); err != nil {
+		return nil, nil, err
+	}
+
+	flag, err := s.repo.FindFlagByID(ctx, flagID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrFlagNotFound
+		}
+		return nil, nil, fmt.Errorf("find flag: %w", err)
+	}
+	if flag.Status != enum.FLAG_PENDING {
+		return nil, nil, ErrFlagReviewed
+	}
+
+	post, err := s.findPost(ctx, flag.PostType, flag.PostID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return flag, post, nil
+}
+
+func (s *moderationService) findPost(ctx context.Context, postType enum.PostTypeEnum, postID uuid.UUID) (*entity.ModeratedPost, error) {
+	post, err := s.repo.FindPost(ctx, postType, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPostNotFound
+		}
+		return nil, fmt.Errorf("find post: %w", err)
+	}
+	return post, nil
+}
+
+func (s *moderationService) require(ctx context.Context, user *jwt.Claims) error {
+	if err := s.reputationSvc.Require(ctx, user.UserID, enum.PRIVILEGE_MODERATE); err != nil {
+		if errors.Is(err, reputationService.ErrInsufficientReputation) {
+			return fmt.Errorf("%w: %w", ErrModerationForbidden, err)
+		}
+		return err
+	}
+	return nil
+}
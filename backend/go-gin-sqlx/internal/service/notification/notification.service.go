@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/notification"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/pagination"
+	"api-stack-underflow/internal/pkg/rabbitmq"
+	notificationRepository "api-stack-underflow/internal/repository/notification"
+	questionRepository "api-stack-underflow/internal/repository/question"
+	userRepository "api-stack-underflow/internal/repository/user"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// NotificationQueue carries NotificationEvent messages to the fan-out consumer
+	NotificationQueue = "notifications"
+
+	notificationPattern = "notification.event"
+	// publishTimeout bounds how long a request waits on the broker
+	publishTimeout = 5 * time.Second
+)
+
+var (
+	// ErrQuestionNotFound mirrors the question service error, importing it would be a cycle
+	ErrQuestionNotFound     = errors.New("question not found")
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+type INotificationService interface {
+	// Notify publishes the event for the queue consumer. It never fails the
+	// caller, a lost notification must not undo the post that triggered it.
+	Notify(ctx context.Context, event entity.NotificationEvent)
+	// Consume fans a queued event out to the inbox of every recipient
+	Consume(ctx context.Context, body []byte) error
+
+	Follow(ctx context.Context, user *jwt.Claims, questionID uuid.UUID) (*dto.FollowResponse, error)
+	Unfollow(ctx context.Context, user *jwt.Claims, questionID uuid.UUID) (*dto.FollowResponse, error)
+	Inbox(ctx context.Context, user *jwt.Claims, p *pagination.Pagination) (pagination.PaginatedResponse[dto.NotificationResponse], error)
+	UnreadCount(ctx context.Context, user *jwt.Claims) (*dto.UnreadCountResponse, error)
+	MarkRead(ctx context.Context, user *jwt.Claims, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, user *jwt.Claims) (*dto.MarkReadResponse, error)
+}
+
+type notificationService struct {
+	repo         notificationRepository.INotificationRepository
+	questionRepo questionRepository.IQuestionRepository
+	userRepo     userRepository.IUserRepository
+	publisher    *rabbitmq.Publisher
+}
+
+// NewNotificationService takes an optional publisher, nil disables notifications
+func NewNotificationService(repo notificationRepository.INotificationRepository, questionRepo questionRepository.IQuestionRepository, userRepo userRepository.IUserRepository, publisher *rabbitmq.Publisher) INotificationService {
+	return &notificationService{repo: repo, questionRepo: questionRepo, userRepo: userRepo, publisher: publisher}
+}
+
+// NotificationPaginationConfig describes the filters and sorts of the inbox
+func NotificationPaginationConfig() pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithFilter("user_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("n")).
+		WithFilter("is_read", pagination.WithDataType("boolean"), pagination.WithTableAlias("n")).
+		WithFilter("type", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("n")).
+		WithFilter("question_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("n")).
+		WithSort("id", pagination.WithSortTableAlias("n")).
+		WithSort("created_at", pagination.WithSortTableAlias("n")).
+		SetDefaultSort("created_at", pagination.WithSortTableAlias("n"))
+	return config
+}
+
+func (s *notificationService) Notify(ctx context.Context, event entity.NotificationEvent) {
+	if s.publisher == nil {
+		return
+	}
+
+	if event.ID == uuid.Nil {
+		event.ID = uuid.New()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	msg, err := rabbitmq.NewMessage(event, nil)
+	if err != nil {
+		logger.Log.Warn().Err(err).Str("event", event.Event.ToString()).Msg("Failed to build notification message")
+		return
+	}
+
+	opts := rabbitmq.DefaultPublishOptions(NotificationQueue, notificationPattern, false)
+	opts.MaxRetries = 1
+	opts.RetryBackoff = time.Second
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	if _, err := s.publisher.PublishWithContext(ctx, msg, opts); err != nil {
+		logger.Log.Warn().Err(err).Str("event", event.Event.ToString()).Str("question_id", event.QuestionID.String()).Msg("Failed to publish notification event")
+	}
+}
+
+func (s *notificationService) Consume(ctx context.Context, body []byte) error {
+	var message struct {
+		Pattern string                   `json:"type"`
+		Data    entity.NotificationEvent `json:"data"`
+	}
+	if err := json.Unmarshal(body, &message); err != nil {
+		return fmt.Errorf("decode notification event: %w", err)
+	}
+	if message.Pattern != notificationPattern {
+		logger.Log.Warn().Str("pattern", message.Pattern).Msg("Skipping unknown notification message")
+		return nil
+	}
+	return s.dispatch(ctx, message.Data)
+}
+
+// dispatch picks one notification type per recipient: a mention beats being
+// the question author, which beats following the question. The actor is never notified.
+func (s *notificationService) dispatch(ctx context.Context, event entity.NotificationEvent) error {
+	question, err := s.questionRepo.FindByID(ctx, event.QuestionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Deleted before the event was consumed, nothing left to point at
+			return nil
+		}
+		return fmt.Errorf("find question: %w", err)
+	}
+
+	recipients := make(map[uuid.UUID]enum.NotificationTypeEnum)
+
+	followers, err := s.repo.FindFollowers(ctx, question.ID)
+	if err != nil {
+		return err
+	}
+	for _, id := range followers {
+		recipients[id] = enum.NOTIFICATION_FOLLOWED_QUESTION
+	}
+
+	switch event.Event {
+	case enum.NOTIFICATION_EVENT_ANSWER_CREATED:
+		recipients[question.UserID] = enum.NOTIFICATION_ANSWER
+	case enum.NOTIFICATION_EVENT_COMMENT_CREATED:
+		recipients[question.UserID] = enum.NOTIFICATION_COMMENT
+	}
+
+	if len(event.Mentions) > 0 {
+		users, err := s.userRepo.FindByUsernames(ctx, event.Mentions)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			recipients[u.ID] = enum.NOTIFICATION_MENTION
+		}
+	}
+
+	delete(recipients, event.ActorID)
+
+	notifications := make([]entity.Notification, 0, len(recipients))
+	for userID, notificationType := range recipients {
+		notifications = append(notifications, entity.Notification{
+			UserID:        userID,
+			EventID:       event.ID,
+			Type:          notificationType,
+			Event:         event.Event,
+			QuestionID:    event.QuestionID,
+			PostType:      event.PostType,
+			PostID:        event.PostID,
+			ActorID:       event.ActorID,
+			ActorUsername: event.ActorUsername,
+		})
+	}
+	return s.repo.CreateMany(ctx, notifications)
+}
+
+func (s *notificationService) Follow(ctx context.Context, user *jwt.Claims, questionID uuid.UUID) (*dto.FollowResponse, error) {
+	if err := s.findQuestion(ctx, questionID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Follow(ctx, questionID, user.UserID); err != nil {
+		return nil, err
+	}
+	return &dto.FollowResponse{QuestionID: questionID, Following: true}, nil
+}
+
+func (s *notificationService) Unfollow(ctx context.Context, user *jwt.Claims, questionID uuid.UUID) (*dto.FollowResponse, error) {
+	if err := s.findQuestion(ctx, questionID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Unfollow(ctx, questionID, user.UserID); err != nil {
+		return nil, err
+	}
+	return &dto.FollowResponse{QuestionID: questionID, Following: false}, nil
+}
+
+func (s *notificationService) Inbox(ctx context.Context, user *jwt.Claims, p *pagination.Pagination) (pagination.PaginatedResponse[dto.NotificationResponse], error) {
+	// The inbox is always the caller's own, a query string user_id must not widen it
+	delete(p.Filters, "user_id")
+	p.PaginationConfig.DefaultFilter["user_id"] = pagination.DefaultFilterField{
+		Value:    user.UserID.String(),
+		Operator: "=",
+	}
+
+	result, err := s.repo.FindAll(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.NotificationResponse]{}, fmt.Errorf("list notifications: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewNotificationResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+func (s *notificationService) UnreadCount(ctx context.Context, user *jwt.Claims) (*dto.UnreadCountResponse, error) {
+	counts, err := s.repo.CountUnread(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.NewUnreadCountResponse(counts)
+	return &response, nil
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, user *jwt.Claims, id uuid.UUID) error {
+	if err := s.repo.MarkRead(ctx, user.UserID, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotificationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, user *jwt.Claims) (*dto.MarkReadResponse, error) {
+	updated, err := s.repo.MarkAllRead(ctx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MarkReadResponse{Updated: updated}, nil
+}
+
+func (s *notificationService) findQuestion(ctx context.Context, id uuid.UUID) error {
+	question, err := s.questionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrQuestionNotFound
+		}
+		return fmt.Errorf("find question: %w", err)
+	}
+	if question.IsHidden {
+		return ErrQuestionNotFound
+	}
+	return nil
+}
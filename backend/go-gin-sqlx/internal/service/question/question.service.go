@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	commentDto "api-stack-underflow/internal/dto/comment"
+	dto "api-stack-underflow/internal/dto/question"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/pagination"
+	commentRepository "api-stack-underflow/internal/repository/comment"
+	questionRepository "api-stack-underflow/internal/repository/question"
+	badgeService "api-stack-underflow/internal/service/badge"
+	notificationService "api-stack-underflow/internal/service/notification"
+	reputationService "api-stack-underflow/internal/service/reputation"
+	revisionService "api-stack-underflow/internal/service/revision"
+	streamService "api-stack-underflow/internal/service/stream"
+	tagService "api-stack-underflow/internal/service/tag"
+	viewService "api-stack-underflow/internal/service/view"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	DefaultRelatedLimit = 10
+	DefaultHotLimit     = 10
+	// HotRefreshInterval is how often the hot ranking is recomputed
+	HotRefreshInterval = 5 * time.Minute
+)
+
+var (
+	ErrQuestionNotFound  = errors.New("question not found")
+	ErrQuestionForbidden = errors.New("not allowed to modify this question")
+	ErrEmptySearchQuery  = errors.New("search query is required")
+	// ErrStatusWorkflowRequired guards the status, it only changes by accepting an
+	// answer or through close and reopen votes
+	ErrStatusWorkflowRequired = errors.New("use the accept, close and reopen actions to change the status")
+	// ErrActiveBounty keeps a question around until its bounty is awarded or refunded
+	ErrActiveBounty = errors.New("question has an active bounty")
+)
+
+type IQuestionService interface {
+	List(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[dto.QuestionResponse], error)
+	Search(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[dto.QuestionSearchResponse], error)
+	// Get returns the question and records the read for its view count
+	Get(ctx context.Context, id uuid.UUID, viewer entity.Viewer) (*dto.QuestionDetailResponse, error)
+	Create(ctx context.Context, user *jwt.Claims, req dto.CreateQuestionRequest) (*dto.QuestionResponse, error)
+	Update(ctx context.Context, user *jwt.Claims, id uuid.UUID, req dto.UpdateQuestionRequest) (*dto.QuestionResponse, error)
+	Delete(ctx context.Context, user *jwt.Claims, id uuid.UUID) error
+	Related(ctx context.Context, id uuid.UUID, limit int) ([]dto.QuestionResponse, error)
+	Hot(ctx context.Context, limit int) ([]dto.QuestionResponse, error)
+	RefreshHot(ctx context.Context) error
+}
+
+type questionService struct {
+	repo            questionRepository.IQuestionRepository
+	commentRepo     commentRepository.ICommentRepository
+	tagSvc          tagService.ITagService
+	reputationSvc   reputationService.IReputationService
+	revisionSvc     revisionService.IRevisionService
+	notificationSvc notificationService.INotificationService
+	streamSvc       streamService.IStreamService
+	badgeSvc        badgeService.IBadgeService
+	viewSvc         viewService.IViewService
+}
+
+func NewQuestionService(repo questionRepository.IQuestionRepository, commentRepo commentRepository.ICommentRepository, tagSvc tagService.ITagService, reputationSvc reputationService.IReputationService, revisionSvc revisionService.IRevisionService, notificationSvc notificationService.INotificationService, streamSvc streamService.IStreamService, badgeSvc badgeService.IBadgeService, viewSvc viewService.IViewService) IQuestionService {
+	return &questionService{repo: repo, commentRepo: commentRepo, tagSvc: tagSvc, reputationSvc: reputationSvc, revisionSvc: revisionSvc, notificationSvc: notificationSvc, streamSvc: streamSvc, badgeSvc: badgeSvc, viewSvc: viewSvc}
+}
+
+// QuestionPaginationConfig describes the filters, search and sorts accepted by the question list
+func QuestionPaginationConfig() pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithFilter("status", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("q")).
+		WithFilter("user_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("q")).
+		WithFilter("tagged", pagination.WithField("tags"), pagination.WithDataType("array"), pagination.WithTableAlias("q")).
+		WithFilter("is_hidden", pagination.WithDataType("boolean"), pagination.WithTableAlias("q")).
+		WithFilter("has_bounty", pagination.WithDataType("boolean"), pagination.WithTableAlias("q")).
+		WithSearch("q",
+			pagination.FieldConfig{Field: "title", TableAlias: "q"},
+			pagination.FieldConfig{Field: "description", TableAlias: "q"},
+		).
+		WithSort("id", pagination.WithSortTableAlias("q")).
+		WithSort("created_at", pagination.WithSortTableAlias("q")).
+		WithSort("score", pagination.WithSortTableAlias("q")).
+		WithSort("view_count", pagination.WithSortTableAlias("q")).
+		WithSort("bounty_amount", pagination.WithSortTableAlias("q")).
+		SetDefaultSort("created_at", pagination.WithSortTableAlias("q"))
+	config.DefaultFilter["is_hidden"] = pagination.DefaultFilterField{
+		Value:    "false",
+		Operator: "=",
+	}
+	return config
+}
+
+func (s *questionService) List(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[dto.QuestionResponse], error) {
+	// Hidden questions only surface through the moderation queue
+	delete(p.Filters, "is_hidden")
+	if err := s.canonicalizeTagged(ctx, p); err != nil {
+		return pagination.PaginatedResponse[dto.QuestionResponse]{}, err
+	}
+
+	result, err := s.repo.FindAll(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.QuestionResponse]{}, fmt.Errorf("list questions: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewQuestionResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+// Search ranks questions by full-text relevance over title, description and comments
+func (s *questionService) Search(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[dto.QuestionSearchResponse], error) {
+	if strings.TrimSpace(p.Filters["q"]) == "" {
+		return pagination.PaginatedResponse[dto.QuestionSearchResponse]{}, ErrEmptySearchQuery
+	}
+	delete(p.Filters, "is_hidden")
+	if err := s.canonicalizeTagged(ctx, p); err != nil {
+		return pagination.PaginatedResponse[dto.QuestionSearchResponse]{}, err
+	}
+
+	result, err := s.repo.Search(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.QuestionSearchResponse]{}, fmt.Errorf("search questions: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewQuestionSearchResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+// canonicalizeTagged rewrites tagged=Go,golang to the canonical names stored on the question
+func (s *questionService) canonicalizeTagged(ctx context.Context, p *pagination.Pagination) error {
+	tagged, ok := p.Filters["tagged"]
+	if !ok {
+		return nil
+	}
+	tags, err := s.tagSvc.Canonicalize(ctx, strings.Split(tagged, ","))
+	if err != nil {
+		return err
+	}
+	p.Filters["tagged"] = strings.Join(tags, ",")
+	return nil
+}
+
+func (s *questionService) Get(ctx context.Context, id uuid.UUID, viewer entity.Viewer) (*dto.QuestionDetailResponse, error) {
+	question, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if question.IsHidden {
+		return nil, ErrQuestionNotFound
+	}
+	s.viewSvc.Record(ctx, id, viewer)
+
+	comments, err := s.commentRepo.FindByQuestionID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("question comments: %w", err)
+	}
+
+	detail := &dto.QuestionDetailResponse{
+		QuestionResponse: dto.NewQuestionResponse(*question),
+		Comments:         commentDto.NewCommentResponses(comments),
+	}
+
+	// Readers of a duplicate get the original to follow
+	if question.DuplicateOf != nil {
+		original, err := s.repo.FindByID(ctx, *question.DuplicateOf)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("find duplicate original: %w", err)
+		}
+		if original != nil {
+			detail.Duplicate = &dto.DuplicateResponse{ID: original.ID, Title: original.Title}
+		}
+	}
+	return detail, nil
+}
+
+func (s *questionService) Create(ctx context.Context, user *jwt.Claims, req dto.CreateQuestionRequest) (*dto.QuestionResponse, error) {
+	tags, err := s.tagSvc.Canonicalize(ctx, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	question := &entity.Question{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      enum.QUESTION_OPEN,
+		UserID:      user.UserID,
+		Username:    user.Username,
+		Tags:        tags,
+	}
+	if err := s.repo.Create(ctx, question); err != nil {
+		return nil, fmt.Errorf("create question: %w", err)
+	}
+	s.badgeSvc.Publish(ctx, entity.DomainEvent{
+		Event:    enum.DOMAIN_EVENT_QUESTION_CREATED,
+		ActorID:  user.UserID,
+		PostType: enum.POST_TYPE_QUESTION,
+		PostID:   question.ID,
+	})
+	response := dto.NewQuestionResponse(*question)
+	return &response, nil
+}
+
+func (s *questionService) Update(ctx context.Context, user *jwt.Claims, id uuid.UUID, req dto.UpdateQuestionRequest) (*dto.QuestionResponse, error) {
+	question, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeUpdate(ctx, user, question, req); err != nil {
+		return nil, err
+	}
+	var tags []string
+	if req.Tags != nil {
+		if tags, err = s.tagSvc.Canonicalize(ctx, *req.Tags); err != nil {
+			return nil, err
+		}
+	}
+
+	var revision *entity.Revision
+	post := revisionService.RevisionPost{Type: enum.POST_TYPE_QUESTION, ID: question.ID, QuestionID: question.ID}
+	question, err = s.repo.Update(ctx, id, func(ctx context.Context, tx *sqlx.Tx, question *entity.Question) error {
+		before := *question
+		if req.Title != nil {
+			question.Title = *req.Title
+		}
+		if req.Description != nil {
+			question.Description = *req.Description
+		}
+		if req.Tags != nil {
+			question.Tags = tags
+		}
+
+		var err error
+		revision, err = s.revisionSvc.Record(ctx, tx, user, post, before, *question, "")
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("update question: %w", err)
+	}
+	// Followers only hear about edits that changed the content
+	if revision != nil {
+		s.notificationSvc.Notify(ctx, entity.NotificationEvent{
+			Event:         enum.NOTIFICATION_EVENT_QUESTION_UPDATED,
+			QuestionID:    question.ID,
+			PostType:      enum.POST_TYPE_QUESTION,
+			PostID:        question.ID,
+			ActorID:       user.UserID,
+			ActorUsername: user.Username,
+		})
+	}
+	response := dto.NewQuestionResponse(*question)
+	return &response, nil
+}
+
+func (s *questionService) Delete(ctx context.Context, user *jwt.Claims, id uuid.UUID) error {
+	question, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	if question.UserID != user.UserID {
+		return ErrQuestionForbidden
+	}
+	if question.BountyAmount > 0 {
+		return ErrActiveBounty
+	}
+	err = s.repo.Delete(ctx, id, func(ctx context.Context, tx *sqlx.Tx, accepted *entity.Answer) error {
+		return s.reputationSvc.RevokePost(ctx, tx, enum.VOTE_TARGET_QUESTION, id, accepted, question.UserID)
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrQuestionNotFound
+		}
+		return fmt.Errorf("delete question: %w", err)
+	}
+	return nil
+}
+
+func (s *questionService) Related(ctx context.Context, id uuid.UUID, limit int) ([]dto.QuestionResponse, error) {
+	if _, err := s.find(ctx, id); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultRelatedLimit
+	}
+	questions, err := s.repo.FindRelated(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("related questions: %w", err)
+	}
+	return dto.NewQuestionResponses(questions), nil
+}
+
+func (s *questionService) Hot(ctx context.Context, limit int) ([]dto.QuestionResponse, error) {
+	if limit <= 0 {
+		limit = DefaultHotLimit
+	}
+	questions, err := s.repo.FindHot(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("hot questions: %w", err)
+	}
+	return dto.NewQuestionResponses(questions), nil
+}
+
+func (s *questionService) RefreshHot(ctx context.Context) error {
+	if err := s.repo.RefreshHot(ctx); err != nil {
+		return fmt.Errorf("refresh hot questions: %w", err)
+	}
+	return nil
+}
+
+// authorizeUpdate lets the author edit freely and others with the edit privilege.
+// The status is never edited directly, accepting an answer and close votes own
+// it. Clients sending the full question back may repeat the current one.
+func (s *questionService) authorizeUpdate(ctx context.Context, user *jwt.Claims, question *entity.Question, req dto.UpdateQuestionRequest) error {
+	if req.Status != nil && *req.Status != question.Status {
+		return ErrStatusWorkflowRequired
+	}
+
+	if question.UserID != user.UserID {
+		return s.require(ctx, user, enum.PRIVILEGE_EDIT_OTHERS)
+	}
+	return nil
+}
+
+func (s *questionService) require(ctx context.Context, user *jwt.Claims, privilege enum.PrivilegeEnum) error {
+	if err := s.reputationSvc.Require(ctx, user.UserID, privilege); err != nil {
+		if errors.Is(err, reputationService.ErrInsufficientReputation) {
+			return fmt.Errorf("%w: %w", ErrQuestionForbidden, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *questionService) find(ctx context.Context, id uuid.UUID) (*entity.Question, error) {
+	question, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("find question: %w", err)
+	}
+	return question, nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/question"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeUpdateStatus(t *testing.T) {
+	author := &jwt.Claims{UserID: uuid.New()}
+	question := &entity.Question{UserID: author.UserID, Status: enum.QUESTION_ANSWERED}
+	status := func(status enum.QuestionStatusEnum) *enum.QuestionStatusEnum { return &status }
+
+	tests := []struct {
+		name     string
+		status   *enum.QuestionStatusEnum
+		expected error
+	}{
+		{"no status", nil, nil},
+		{"unchanged status", status(enum.QUESTION_ANSWERED), nil},
+		{"reopened", status(enum.QUESTION_OPEN), ErrStatusWorkflowRequired},
+		{"closed", status(enum.QUESTION_CLOSED), ErrStatusWorkflowRequired},
+	}
+
+	s := &questionService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.authorizeUpdate(context.Background(), author, question, dto.UpdateQuestionRequest{Status: tt.status})
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
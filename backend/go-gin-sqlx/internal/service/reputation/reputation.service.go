@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/config"
+	dto "api-stack-underflow/internal/dto/reputation"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/pagination"
+	reputationRepository "api-stack-underflow/internal/repository/reputation"
+	roleRepository "api-stack-underflow/internal/repository/role"
+	userRepository "api-stack-underflow/internal/repository/user"
+	voteRepository "api-stack-underflow/internal/repository/vote"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Reputation awarded per event, reversals apply the negated amount
+const (
+	UpvoteReceived   = 10
+	DownvoteReceived = -2
+	DownvoteCast     = -1
+	AnswerAccepted   = 15
+	AcceptBonus      = 2
+)
+
+var (
+	ErrUserNotFound           = errors.New("user not found")
+	ErrInsufficientReputation = errors.New("insufficient reputation")
+)
+
+type IReputationService interface {
+	History(ctx context.Context, username string, p *pagination.Pagination) (*dto.ReputationHistoryResponse, error)
+	// Require returns ErrInsufficientReputation when the user is below the
+	// privilege threshold and none of their roles grants its permission
+	Require(ctx context.Context, userID uuid.UUID, privilege enum.PrivilegeEnum) error
+	Apply(ctx context.Context, tx *sqlx.Tx, events []entity.ReputationEvent) error
+	// RevokePost takes back what a post about to be deleted earned, from its
+	// votes and, when given, the accepted answer going away with it. The votes
+	// are deleted too so a repost starts from nothing.
+	RevokePost(ctx context.Context, tx *sqlx.Tx, target enum.VoteTargetEnum, postID uuid.UUID, accepted *entity.Answer, questionOwnerID uuid.UUID) error
+}
+
+type reputationService struct {
+	repo     reputationRepository.IReputationRepository
+	userRepo userRepository.IUserRepository
+	roleRepo roleRepository.IRoleRepository
+	voteRepo voteRepository.IVoteRepository
+}
+
+func NewReputationService(repo reputationRepository.IReputationRepository, userRepo userRepository.IUserRepository, roleRepo roleRepository.IRoleRepository, voteRepo voteRepository.IVoteRepository) IReputationService {
+	return &reputationService{repo: repo, userRepo: userRepo, roleRepo: roleRepo, voteRepo: voteRepo}
+}
+
+// privilegePermissions lets a role grant a privilege regardless of reputation,
+// down voting is earned only
+var privilegePermissions = map[enum.PrivilegeEnum]enum.PermissionEnum{
+	enum.PRIVILEGE_EDIT_OTHERS:    enum.PERMISSION_POSTS_EDIT,
+	enum.PRIVILEGE_CLOSE_QUESTION: enum.PERMISSION_QUESTIONS_CLOSE,
+	enum.PRIVILEGE_MODERATE:       enum.PERMISSION_QUESTIONS_MODERATE,
+	enum.PRIVILEGE_MANAGE_TAGS:    enum.PERMISSION_TAGS_MANAGE,
+	enum.PRIVILEGE_FLAG:           enum.PERMISSION_QUESTIONS_MODERATE,
+}
+
+// ReputationPaginationConfig describes the ledger list; History scopes it to one user
+func ReputationPaginationConfig() pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithFilter("user_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("r")).
+		WithFilter("reason", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("r")).
+		WithSort("created_at", pagination.WithSortTableAlias("r")).
+		SetDefaultSort("created_at", pagination.WithSortTableAlias("r"))
+	return config
+}
+
+// Threshold returns the configured minimum reputation of a privilege
+func Threshold(privilege enum.PrivilegeEnum) int {
+	switch privilege {
+	case enum.PRIVILEGE_DOWN_VOTE:
+		return config.Config.Reputation.DownVote
+	case enum.PRIVILEGE_EDIT_OTHERS:
+		return config.Config.Reputation.EditOthers
+	case enum.PRIVILEGE_CLOSE_QUESTION:
+		return config.Config.Reputation.CloseQuestion
+	case enum.PRIVILEGE_MODERATE:
+		return config.Config.Reputation.Moderate
+	case enum.PRIVILEGE_MANAGE_TAGS:
+		return config.Config.Reputation.ManageTags
+	case enum.PRIVILEGE_FLAG:
+		return config.Config.Reputation.Flag
+	default:
+		return 0
+	}
+}
+
+// VoteEvents returns the ledger rows moving a voter's vote from previous to next (-1, 0 or +1)
+func VoteEvents(postType enum.VoteTargetEnum, postID, ownerID, voterID uuid.UUID, previous, next int) []entity.ReputationEvent {
+	if previous == next {
+		return nil
+	}
+
+	effects := func(value, sign int) []entity.ReputationEvent {
+		event := func(userID uuid.UUID, amount int, reason enum.ReputationReasonEnum) entity.ReputationEvent {
+			return entity.ReputationEvent{
+				UserID:   userID,
+				Amount:   amount * sign,
+				Reason:   reason,
+				PostType: postType,
+				PostID:   postID,
+				ActorID:  &voterID,
+			}
+		}
+
+		switch value {
+		case 1:
+			return []entity.ReputationEvent{event(ownerID, UpvoteReceived, enum.REPUTATION_UPVOTE_RECEIVED)}
+		case -1:
+			events := []entity.ReputationEvent{event(ownerID, DownvoteReceived, enum.REPUTATION_DOWNVOTE_RECEIVED)}
+			if postType == enum.VOTE_TARGET_ANSWER {
+				events = append(events, event(voterID, DownvoteCast, enum.REPUTATION_DOWNVOTE_CAST))
+			}
+			return events
+		}
+		return nil
+	}
+
+	return append(effects(previous, -1), effects(next, 1)...)
+}
+
+// AcceptEvents returns the ledger rows for accepting answer, revoking a previously accepted one.
+// A nil answer only revokes the previous one. Accepting your own answer earns nothing.
+func AcceptEvents(accepted *entity.Answer, previous *entity.Answer, questionOwnerID uuid.UUID) []entity.ReputationEvent {
+	events := []entity.ReputationEvent{}
+
+	grant := func(answer *entity.Answer, sign int) {
+		if answer.UserID == questionOwnerID {
+			return
+		}
+		events = append(events,
+			entity.ReputationEvent{
+				UserID:   answer.UserID,
+				Amount:   AnswerAccepted * sign,
+				Reason:   enum.REPUTATION_ANSWER_ACCEPTED,
+				PostType: enum.VOTE_TARGET_ANSWER,
+				PostID:   answer.ID,
+				ActorID:  &questionOwnerID,
+			},
+			entity.ReputationEvent{
+				UserID:   questionOwnerID,
+				Amount:   AcceptBonus * sign,
+				Reason:   enum.REPUTATION_ACCEPT_BONUS,
+				PostType: enum.VOTE_TARGET_ANSWER,
+				PostID:   answer.ID,
+				ActorID:  &questionOwnerID,
+			},
+		)
+	}
+
+	if previous != nil {
+		if accepted != nil && previous.ID == accepted.ID {
+			return events
+		}
+		grant(previous, -1)
+	}
+	if accepted != nil {
+		grant(accepted, 1)
+	}
+	return events
+}
+
+func (s *reputationService) History(ctx context.Context, username string, p *pagination.Pagination) (*dto.ReputationHistoryResponse, error) {
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	// The path already scopes the ledger, a query string user_id must not widen it
+	delete(p.Filters, "user_id")
+	p.PaginationConfig.DefaultFilter["user_id"] = pagination.DefaultFilterField{
+		Value:    user.ID.String(),
+		Operator: "=",
+	}
+
+	result, err := s.repo.FindAll(ctx, p)
+	if err != nil {
+		return nil, fmt.Errorf("reputation history: %w", err)
+	}
+
+	return &dto.ReputationHistoryResponse{
+		UserID:     user.ID,
+		Username:   user.Username,
+		Reputation: user.Reputation,
+		Events:     dto.NewReputationEventResponses(result.Data),
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+func (s *reputationService) Require(ctx context.Context, userID uuid.UUID, privilege enum.PrivilegeEnum) error {
+	reputation, err := s.repo.GetReputation(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("get reputation: %w", err)
+	}
+
+	threshold := Threshold(privilege)
+	if reputation >= threshold {
+		return nil
+	}
+
+	if permission, ok := privilegePermissions[privilege]; ok {
+		permissions, err := s.roleRepo.FindUserPermissions(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(permissions, permission) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s requires %d reputation", ErrInsufficientReputation, privilege, threshold)
+}
+
+func (s *reputationService) Apply(ctx context.Context, tx *sqlx.Tx, events []entity.ReputationEvent) error {
+	return s.repo.Apply(ctx, tx, events)
+}
+
+func (s *reputationService) RevokePost(ctx context.Context, tx *sqlx.Tx, target enum.VoteTargetEnum, postID uuid.UUID, accepted *entity.Answer, questionOwnerID uuid.UUID) error {
+	votes, err := s.voteRepo.DeleteForPost(ctx, tx, target, postID)
+	if err != nil {
+		return err
+	}
+
+	events := []entity.ReputationEvent{}
+	for _, vote := range votes {
+		events = append(events, VoteEvents(vote.TargetType, vote.TargetID, vote.OwnerID, vote.UserID, vote.Value, 0)...)
+	}
+	if accepted != nil {
+		events = append(events, AcceptEvents(nil, accepted, questionOwnerID)...)
+	}
+	return s.repo.Apply(ctx, tx, events)
+}
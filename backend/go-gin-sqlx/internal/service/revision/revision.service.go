@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/revision"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/helper"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/pagination"
+	answerRepository "api-stack-underflow/internal/repository/answer"
+	commentRepository "api-stack-underflow/internal/repository/comment"
+	questionRepository "api-stack-underflow/internal/repository/question"
+	revisionRepository "api-stack-underflow/internal/repository/revision"
+	reputationService "api-stack-underflow/internal/service/reputation"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrRevisionNotFound  = errors.New("revision not found")
+	ErrPostNotFound      = errors.New("post not found")
+	ErrRevisionForbidden = errors.New("not allowed to roll back this post")
+	ErrNothingToRollback = errors.New("post already matches this revision")
+)
+
+// trackedFields are the user-editable fields kept in a revision, everything
+// else (status, score, timestamps) has its own history or none at all
+var trackedFields = map[enum.PostTypeEnum][]string{
+	enum.POST_TYPE_QUESTION: {"title", "description", "tags"},
+	enum.POST_TYPE_ANSWER:   {"content"},
+	enum.POST_TYPE_COMMENT:  {"content"},
+}
+
+// RevisionPost identifies the edited post; QuestionID is the post itself for questions
+type RevisionPost struct {
+	Type       enum.PostTypeEnum
+	ID         uuid.UUID
+	QuestionID uuid.UUID
+}
+
+type IRevisionService interface {
+	// Record stores the tracked differences between before and after, it is a no-op when nothing changed.
+	// It runs in the transaction of the edit, with before read under its row lock.
+	Record(ctx context.Context, tx *sqlx.Tx, user *jwt.Claims, post RevisionPost, before, after interface{}, notes string) (*entity.Revision, error)
+	List(ctx context.Context, questionID uuid.UUID, p *pagination.Pagination) (pagination.PaginatedResponse[dto.RevisionResponse], error)
+	Rollback(ctx context.Context, user *jwt.Claims, questionID, revisionID uuid.UUID) (*dto.RevisionResponse, error)
+}
+
+type revisionService struct {
+	repo          revisionRepository.IRevisionRepository
+	questionRepo  questionRepository.IQuestionRepository
+	answerRepo    answerRepository.IAnswerRepository
+	commentRepo   commentRepository.ICommentRepository
+	reputationSvc reputationService.IReputationService
+}
+
+func NewRevisionService(
+	repo revisionRepository.IRevisionRepository,
+	questionRepo questionRepository.IQuestionRepository,
+	answerRepo answerRepository.IAnswerRepository,
+	commentRepo commentRepository.ICommentRepository,
+	reputationSvc reputationService.IReputationService,
+) IRevisionService {
+	return &revisionService{
+		repo:          repo,
+		questionRepo:  questionRepo,
+		answerRepo:    answerRepo,
+		commentRepo:   commentRepo,
+		reputationSvc: reputationSvc,
+	}
+}
+
+// RevisionPaginationConfig scopes the revision list to one question and its answers and comments
+func RevisionPaginationConfig(questionID uuid.UUID) pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithFilter("question_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("rv")).
+		WithFilter("post_type", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("rv")).
+		WithFilter("post_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("rv")).
+		WithSort("created_at", pagination.WithSortTableAlias("rv")).
+		SetDefaultSort("created_at", pagination.WithSortTableAlias("rv"))
+	config.DefaultFilter["question_id"] = pagination.DefaultFilterField{
+		Value:    questionID.String(),
+		Operator: "=",
+	}
+	return config
+}
+
+// Diff returns the tracked fields that differ between two versions of a post
+func Diff(postType enum.PostTypeEnum, before, after interface{}) helper.Changes {
+	changes := helper.TrackChanges(before, after)
+
+	tracked := make(helper.Changes, len(changes))
+	for _, field := range trackedFields[postType] {
+		if change, ok := changes[field]; ok {
+			tracked[field] = change
+		}
+	}
+	return tracked
+}
+
+func (s *revisionService) Record(ctx context.Context, tx *sqlx.Tx, user *jwt.Claims, post RevisionPost, before, after interface{}, notes string) (*entity.Revision, error) {
+	payload, err := helper.BuildHistoryPayload(Diff(post.Type, before, after), notes)
+	if err != nil {
+		return nil, err
+	}
+	if payload == nil {
+		return nil, nil
+	}
+
+	revision := &entity.Revision{
+		QuestionID: post.QuestionID,
+		PostType:   post.Type,
+		PostID:     post.ID,
+		UserID:     &user.UserID,
+		Username:   user.Username,
+		Payload:    payload,
+	}
+	if err := s.repo.Create(ctx, tx, revision); err != nil {
+		return nil, fmt.Errorf("record revision: %w", err)
+	}
+	return revision, nil
+}
+
+func (s *revisionService) List(ctx context.Context, questionID uuid.UUID, p *pagination.Pagination) (pagination.PaginatedResponse[dto.RevisionResponse], error) {
+	question, err := s.questionRepo.FindByID(ctx, questionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return pagination.PaginatedResponse[dto.RevisionResponse]{}, ErrPostNotFound
+		}
+		return pagination.PaginatedResponse[dto.RevisionResponse]{}, fmt.Errorf("find question: %w", err)
+	}
+	// Hidden posts keep their history out of sight, like the posts themselves
+	if question.IsHidden {
+		return pagination.PaginatedResponse[dto.RevisionResponse]{}, ErrPostNotFound
+	}
+
+	// The path already scopes the list, a query string question_id must not widen it
+	delete(p.Filters, "question_id")
+
+	result, err := s.repo.FindAll(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.RevisionResponse]{}, fmt.Errorf("list revisions: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewRevisionResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+// Rollback writes the post back to its state right after the chosen revision
+// and records that as a new revision
+func (s *revisionService) Rollback(ctx context.Context, user *jwt.Claims, questionID, revisionID uuid.UUID) (*dto.RevisionResponse, error) {
+	revision, err := s.repo.FindByID(ctx, revisionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("find revision: %w", err)
+	}
+	if revision.QuestionID != questionID {
+		return nil, ErrRevisionNotFound
+	}
+
+	history, err := s.repo.FindByPost(ctx, revision.PostType, revision.PostID)
+	if err != nil {
+		return nil, err
+	}
+	state, err := stateAt(history, revision.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	post := RevisionPost{Type: revision.PostType, ID: revision.PostID, QuestionID: revision.QuestionID}
+	notes := fmt.Sprintf("rollback to revision %s", revision.ID)
+
+	var restored *entity.Revision
+	switch revision.PostType {
+	case enum.POST_TYPE_QUESTION:
+		restored, err = s.rollbackQuestion(ctx, user, post, state, notes)
+	case enum.POST_TYPE_ANSWER:
+		restored, err = s.rollbackAnswer(ctx, user, post, state, notes)
+	case enum.POST_TYPE_COMMENT:
+		restored, err = s.rollbackComment(ctx, user, post, state, notes)
+	default:
+		return nil, fmt.Errorf("unknown revision post type %q", revision.PostType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.NewRevisionResponse(*restored)
+	return &response, nil
+}
+
+func (s *revisionService) rollbackQuestion(ctx context.Context, user *jwt.Claims, post RevisionPost, state []byte, notes string) (*entity.Revision, error) {
+	question, err := s.questionRepo.FindByID(ctx, post.ID)
+	if err != nil {
+		return nil, postLookupError(err)
+	}
+	if err := s.authorize(ctx, user, question.UserID, true); err != nil {
+		return nil, err
+	}
+
+	var restored *entity.Revision
+	_, err = s.questionRepo.Update(ctx, post.ID, func(ctx context.Context, tx *sqlx.Tx, question *entity.Question) error {
+		before := *question
+		if err := json.Unmarshal(state, question); err != nil {
+			return fmt.Errorf("restore question: %w", err)
+		}
+		revision, err := s.record(ctx, tx, user, post, before, *question, notes)
+		restored = revision
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+func (s *revisionService) rollbackAnswer(ctx context.Context, user *jwt.Claims, post RevisionPost, state []byte, notes string) (*entity.Revision, error) {
+	answer, err := s.answerRepo.FindByID(ctx, post.ID)
+	if err != nil {
+		return nil, postLookupError(err)
+	}
+	if err := s.authorize(ctx, user, answer.UserID, true); err != nil {
+		return nil, err
+	}
+
+	var restored *entity.Revision
+	_, err = s.answerRepo.Update(ctx, post.ID, func(ctx context.Context, tx *sqlx.Tx, answer *entity.Answer) error {
+		before := *answer
+		if err := json.Unmarshal(state, answer); err != nil {
+			return fmt.Errorf("restore answer: %w", err)
+		}
+		revision, err := s.record(ctx, tx, user, post, before, *answer, notes)
+		restored = revision
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+func (s *revisionService) rollbackComment(ctx context.Context, user *jwt.Claims, post RevisionPost, state []byte, notes string) (*entity.Revision, error) {
+	comment, err := s.commentRepo.FindByID(ctx, post.ID)
+	if err != nil {
+		return nil, postLookupError(err)
+	}
+	if err := s.authorize(ctx, user, comment.UserID, false); err != nil {
+		return nil, err
+	}
+
+	var restored *entity.Revision
+	_, err = s.commentRepo.Update(ctx, post.ID, func(ctx context.Context, tx *sqlx.Tx, comment *entity.Comment) error {
+		before := *comment
+		if err := json.Unmarshal(state, comment); err != nil {
+			return fmt.Errorf("restore comment: %w", err)
+		}
+		revision, err := s.record(ctx, tx, user, post, before, *comment, notes)
+		restored = revision
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// record stores the revision of a rollback, failing with ErrNothingToRollback
+// when the locked post already matches the restored state
+func (s *revisionService) record(ctx context.Context, tx *sqlx.Tx, user *jwt.Claims, post RevisionPost, before, after interface{}, notes string) (*entity.Revision, error) {
+	revision, err := s.Record(ctx, tx, user, post, before, after, notes)
+	if err != nil {
+		return nil, err
+	}
+	if revision == nil {
+		return nil, ErrNothingToRollback
+	}
+	return revision, nil
+}
+
+// authorize mirrors the edit rules: authors always, others only with the
+// edit privilege and only where the post type allows it
+func (s *revisionService) authorize(ctx context.Context, user *jwt.Claims, ownerID uuid.UUID, othersMayEdit bool) error {
+	if ownerID == user.UserID {
+		return nil
+	}
+	if !othersMayEdit {
+		return ErrRevisionForbidden
+	}
+	if err := s.reputationSvc.Require(ctx, user.UserID, enum.PRIVILEGE_EDIT_OTHERS); err != nil {
+		if errors.Is(err, reputationService.ErrInsufficientReputation) {
+			return fmt.Errorf("%w: %w", ErrRevisionForbidden, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// stateAt rebuilds the tracked fields of a post as they were right after the
+// given revision. Fields the revision and its predecessors touched take their
+// latest "new" value, fields first touched later take the "old" value of the
+// earliest later revision, untouched fields are left out so they keep their
+// current value. The result is a JSON object that can be unmarshalled onto the entity.
+func stateAt(history []entity.Revision, revisionID uuid.UUID) ([]byte, error) {
+	target := -1
+	for i, revision := range history {
+		if revision.ID == revisionID {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		return nil, ErrRevisionNotFound
+	}
+
+	state := make(map[string]json.RawMessage)
+	for i := 0; i <= target; i++ {
+		payload, err := history[i].DecodePayload()
+		if err != nil {
+			return nil, fmt.Errorf("decode revision %s: %w", history[i].ID, err)
+		}
+		for field, change := range payload.Changes {
+			state[field] = change.New
+		}
+	}
+	for i := target + 1; i < len(history); i++ {
+		payload, err := history[i].DecodePayload()
+		if err != nil {
+			return nil, fmt.Errorf("decode revision %s: %w", history[i].ID, err)
+		}
+		for field, change := range payload.Changes {
+			if _, ok := state[field]; !ok {
+				state[field] = change.Old
+			}
+		}
+	}
+
+	return json.Marshal(state)
+}
+
+func postLookupError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPostNotFound
+	}
+	return fmt.Errorf("find post: %w", err)
+}
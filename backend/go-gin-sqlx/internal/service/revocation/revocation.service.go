@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/redis"
+	tokenRepository "api-stack-underflow/internal/repository/token"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// LocalCacheTTL is how long an instance reuses a lookup. Revocations reach
+	// every instance through pub/sub right away, the TTL only bounds the delay
+	// when a message is missed or Redis is not configured.
+	LocalCacheTTL = 5 * time.Second
+
+	revokedKeyPrefix   = "auth:revoked:"
+	watermarkKeyPrefix = "auth:revoked_before:"
+	revocationChannel  = "auth:revocations"
+	// localCacheSize bounds the tokens and users remembered by an instance
+	localCacheSize = 10000
+)
+
+type IRevocationService interface {
+	// IsRevoked implements jwt.RevocationStore. Lookups are answered from the
+	// local cache, then Redis, then the database when Redis is missing or failing.
+	IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error)
+	// RevokeToken revokes a single token until it expires, e.g. on logout
+	RevokeToken(ctx context.Context, claims *jwt.Claims) error
+	// RevokeUser revokes every token of the user issued until now, e.g. after
+	// a password change or a ban
+	RevokeUser(ctx context.Context, userID uuid.UUID) error
+	// Run applies revocations made on other instances until ctx is cancelled
+	Run(ctx context.Context)
+}
+
+type cachedToken struct {
+	revoked bool
+	until   time.Time
+}
+
+type cachedWatermark struct {
+	before time.Time
+	until  time.Time
+}
+
+// revocationService keeps each watermark for watermarkTTL, the longest token
+// lifetime, past which it no longer revokes anything
+type revocationService struct {
+	tokenRepo    tokenRepository.ITokenRepository
+	cache        *redis.Client
+	watermarkTTL time.Duration
+
+	mu         sync.Mutex
+	tokens     map[string]cachedToken
+	watermarks map[uuid.UUID]cachedWatermark
+}
+
+// NewRevocationService takes an optional cache. The database always keeps the
+// revocations, Redis serves the lookups of every instance when configured.
+// maxTokenTTL is the lifetime of the longest lived token issued.
+func NewRevocationService(tokenRepo tokenRepository.ITokenRepository, cache *redis.Client, maxTokenTTL time.Duration) IRevocationService {
+	return &revocationService{
+		tokenRepo:    tokenRepo,
+		cache:        cache,
+		watermarkTTL: maxTokenTTL,
+		tokens:       make(map[string]cachedToken),
+		watermarks:   make(map[uuid.UUID]cachedWatermark),
+	}
+}
+
+func (s *revocationService) IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	token, tokenKnown := s.tokens[claims.ID]
+	tokenKnown = tokenKnown && now.Before(token.until)
+	watermark, watermarkKnown := s.watermarks[claims.UserID]
+	watermarkKnown = watermarkKnown && now.Before(watermark.until)
+	s.mu.Unlock()
+
+	if tokenKnown && token.revoked {
+		return true, nil
+	}
+	if watermarkKnown && issuedBefore(claims, watermark.before) {
+		return true, nil
+	}
+	if tokenKnown && watermarkKnown {
+		return false, nil
+	}
+
+	revoked, before, err := s.lookup(ctx, claims)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rememberToken(claims.ID, revoked, claims.ExpiresAt.Time, now)
+	s.rememberWatermark(claims.UserID, before, now)
+	return revoked || issuedBefore(claims, before), nil
+}
+
+// issuedBefore reports whether the token falls under the watermark. Both have
+// microsecond precision, so a token issued right after RevokeUser, e.g. by the
+// login following a password change, stays valid. A token without an issue
+// time is revoked.
+func issuedBefore(claims *jwt.Claims, before time.Time) bool {
+	if before.IsZero() {
+		return false
+	}
+	issuedAt := claims.IssuedAtTime()
+	return issuedAt.IsZero() || !issuedAt.After(before)
+}
+
+// lookup returns whether the token id is revoked and the watermark of its
+// user, the zero time when there is none
+func (s *revocationService) lookup(ctx context.Context, claims *jwt.Claims) (bool, time.Time, error) {
+	if s.cache != nil {
+		values, err := s.cache.MGet(revokedKeyPrefix+claims.ID, watermarkKeyPrefix+claims.UserID.String())
+		if err == nil {
+			var before time.Time
+			if micro, err := strconv.ParseInt(values[1], 10, 64); err == nil {
+				before = time.UnixMicro(micro)
+			}
+			return values[0] != "", before, nil
+		}
+		logger.Log.Warn().Err(err).Msg("Failed to check token revocation in redis, falling back to the database")
+	}
+
+	revoked, err := s.tokenRepo.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	before, err := s.tokenRepo.RevokedBefore(ctx, claims.UserID)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	if before == nil {
+		return revoked, time.Time{}, nil
+	}
+	return revoked, *before, nil
+}
+
+func (s *revocationService) RevokeToken(ctx context.Context, claims *jwt.Claims) error {
+	expiresAt := claims.ExpiresAt.Time
+	if err := s.tokenRepo.Revoke(ctx, claims.ID, claims.UserID, expiresAt); err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		if ttl := time.Until(expiresAt); ttl > 0 {
+			if err := s.cache.Set(revokedKeyPrefix+claims.ID, 1, ttl); err != nil {
+				return fmt.Errorf("cache revoked token: %w", err)
+			}
+		}
+	}
+
+	revocation := entity.Revocation{JTI: claims.ID, UserID: claims.UserID, ExpiresAt: expiresAt}
+	s.apply(revocation)
+	s.broadcast(revocation)
+	return nil
+}
+
+func (s *revocationService) RevokeUser(ctx context.Context, userID uuid.UUID) error {
+	// Stored at the precision of the issue time it is compared with, which is
+	// also what the database keeps
+	before := time.Now().Truncate(time.Microsecond)
+	if err := s.tokenRepo.RevokeUser(ctx, userID, before); err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		if err := s.cache.Set(watermarkKeyPrefix+userID.String(), before.UnixMicro(), s.watermarkTTL); err != nil {
+			return fmt.Errorf("cache token watermark: %w", err)
+		}
+	}
+
+	revocation := entity.Revocation{UserID: userID, ExpiresAt: before.Add(s.watermarkTTL), RevokedBefore: &before}
+	s.apply(revocation)
+	s.broadcast(revocation)
+	return nil
+}
+
+// broadcast tells the other instances to drop what they cached. A failure is
+// logged, they catch up once their entry is older than LocalCacheTTL.
+func (s *revocationService) broadcast(revocation entity.Revocation) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Publish(revocationChannel, revocation); err != nil {
+		logger.Log.Warn().Err(err).Str("user_id", revocation.UserID.String()).Msg("Failed to broadcast token revocation")
+	}
+}
+
+func (s *revocationService) apply(revocation entity.Revocation) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if revocation.JTI != "" {
+		s.rememberToken(revocation.JTI, true, revocation.ExpiresAt, now)
+	}
+	if revocation.RevokedBefore != nil {
+		s.rememberWatermark(revocation.UserID, *revocation.RevokedBefore, now)
+	}
+}
+
+// rememberToken keeps a revoked token until it expires, it cannot come back,
+// and a valid one for LocalCacheTTL. A lookup finishing after a broadcast never
+// clears the revocation. The caller holds s.mu.
+func (s *revocationService) rememberToken(jti string, revoked bool, expiresAt, now time.Time) {
+	if current, ok := s.tokens[jti]; ok && current.revoked && now.Before(current.until) {
+		return
+	}
+	until := now.Add(LocalCacheTTL)
+	if revoked {
+		until = expiresAt
+	}
+	if len(s.tokens) >= localCacheSize {
+		for key, token := range s.tokens {
+			if !now.Before(token.until) {
+				delete(s.tokens, key)
+			}
+		}
+		if len(s.tokens) >= localCacheSize {
+			s.tokens = make(map[string]cachedToken)
+		}
+	}
+	s.tokens[jti] = cachedToken{revoked: revoked, until: until}
+}
+
+// rememberWatermark caches the watermark of a user for LocalCacheTTL, it only
+// ever moves forward. The caller holds s.mu.
+func (s *revocationService) rememberWatermark(userID uuid.UUID, before, now time.Time) {
+	if current, ok := s.watermarks[userID]; ok && current.before.After(before) {
+		before = current.before
+	}
+	if len(s.watermarks) >= localCacheSize {
+		for key, watermark := range s.watermarks {
+			if !now.Before(watermark.until) {
+				delete(s.watermarks, key)
+			}
+		}
+		if len(s.watermarks) >= localCacheSize {
+			s.watermarks = make(map[uuid.UUID]cachedWatermark)
+		}
+	}
+	s.watermarks[userID] = cachedWatermark{before: before, until: now.Add(LocalCacheTTL)}
+}
+
+func (s *revocationService) Run(ctx context.Context) {
+	if s.cache == nil {
+		return
+	}
+
+	pubsub, err := s.cache.PSubscribe(ctx, revocationChannel)
+	if err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to subscribe to token revocations, other instances see them after the local cache expires")
+		return
+	}
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var revocation entity.Revocation
+			if err := json.Unmarshal([]byte(msg.Payload), &revocation); err != nil {
+				logger.Log.Warn().Err(err).Msg("Skipping malformed token revocation")
+				continue
+			}
+			s.apply(revocation)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/role"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	roleRepository "api-stack-underflow/internal/repository/role"
+	userRepository "api-stack-underflow/internal/repository/user"
+	revocationService "api-stack-underflow/internal/service/revocation"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleExists        = errors.New("role code is already taken")
+	ErrRoleNotAssigned   = errors.New("user does not have the role")
+	ErrInvalidPermission = errors.New("unknown permission")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrCannotBanSelf     = errors.New("you cannot ban yourself")
+)
+
+type IRoleService interface {
+	List(ctx context.Context) ([]dto.RoleResponse, error)
+	Get(ctx context.Context, id uuid.UUID) (*dto.RoleResponse, error)
+	Create(ctx context.Context, req dto.CreateRoleRequest) (*dto.RoleResponse, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.UpdateRoleRequest) (*dto.RoleResponse, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// UserPermissions lists the roles of the user and what their active ones grant
+	UserPermissions(ctx context.Context, userID uuid.UUID) (*dto.UserPermissionsResponse, error)
+	Assign(ctx context.Context, user *jwt.Claims, userID uuid.UUID, req dto.AssignRoleRequest) (*dto.UserPermissionsResponse, error)
+	Unassign(ctx context.Context, userID, roleID uuid.UUID) (*dto.UserPermissionsResponse, error)
+	// Ban stops the user from signing in and revokes every token it holds
+	Ban(ctx context.Context, user *jwt.Claims, userID uuid.UUID) error
+	Unban(ctx context.Context, userID uuid.UUID) error
+	// HasPermission implements middleware.PermissionChecker
+	HasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error)
+}
+
+type roleService struct {
+	repo          roleRepository.IRoleRepository
+	userRepo      userRepository.IUserRepository
+	revocationSvc revocationService.IRevocationService
+}
+
+func NewRoleService(repo roleRepository.IRoleRepository, userRepo userRepository.IUserRepository, revocationSvc revocationService.IRevocationService) IRoleService {
+	return &roleService{repo: repo, userRepo: userRepo, revocationSvc: revocationSvc}
+}
+
+func (s *roleService) List(ctx context.Context) ([]dto.RoleResponse, error) {
+	roles, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewRoleResponses(roles), nil
+}
+
+func (s *roleService) Get(ctx context.Context, id uuid.UUID) (*dto.RoleResponse, error) {
+	role, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.NewRoleResponse(*role)
+	return &response, nil
+}
+
+func (s *roleService) Create(ctx context.Context, req dto.CreateRoleRequest) (*dto.RoleResponse, error) {
+	code := NormalizeRoleCode(req.Code)
+	if err := s.ensureCodeFree(ctx, code, uuid.Nil); err != nil {
+		return nil, err
+	}
+	permissions, err := normalizePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &entity.Role{
+		Code:        code,
+		Name:        req.Name,
+		Description: req.Description,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+		Permissions: permissions,
+	}
+	if err := s.repo.Create(ctx, role); err != nil {
+		return nil, fmt.Errorf("create role: %w", err)
+	}
+
+	response := dto.NewRoleResponse(*role)
+	return &response, nil
+}
+
+func (s *roleService) Update(ctx context.Context, id uuid.UUID, req dto.UpdateRoleRequest) (*dto.RoleResponse, error) {
+	role, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Code != "" {
+		code := NormalizeRoleCode(req.Code)
+		if err := s.ensureCodeFree(ctx, code, role.ID); err != nil {
+			return nil, err
+		}
+		role.Code = code
+	}
+	if req.Name != "" {
+		role.Name = req.Name
+	}
+	if req.Description != "" {
+		role.Description = req.Description
+	}
+	if req.IsActive != nil {
+		role.IsActive = *req.IsActive
+	}
+	if req.Permissions != nil {
+		if role.Permissions, err = normalizePermissions(*req.Permissions); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Update(ctx, role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("update role: %w", err)
+	}
+
+	response := dto.NewRoleResponse(*role)
+	return &response, nil
+}
+
+func (s *roleService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		return fmt.Errorf("delete role: %w", err)
+	}
+	return nil
+}
+
+func (s *roleService) UserPermissions(ctx context.Context, userID uuid.UUID) (*dto.UserPermissionsResponse, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	roles, err := s.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := s.repo.FindUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.UserPermissionsResponse{
+		UserID:      userID,
+		Roles:       dto.NewRoleResponses(roles),
+		Permissions: permissions,
+	}, nil
+}
+
+func (s *roleService) Assign(ctx context.Context, user *jwt.Claims, userID uuid.UUID, req dto.AssignRoleRequest) (*dto.UserPermissionsResponse, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	if _, err := s.find(ctx, req.RoleID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Assign(ctx, userID, req.RoleID, &user.UserID); err != nil {
+		return nil, err
+	}
+	return s.UserPermissions(ctx, userID)
+}
+
+func (s *roleService) Unassign(ctx context.Context, userID, roleID uuid.UUID) (*dto.UserPermissionsResponse, error) {
+	if err := s.repo.Unassign(ctx, userID, roleID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRoleNotAssigned
+		}
+		return nil, err
+	}
+	return s.UserPermissions(ctx, userID)
+}
+
+func (s *roleService) Ban(ctx context.Context, user *jwt.Claims, userID uuid.UUID) error {
+	if user.UserID == userID {
+		return ErrCannotBanSelf
+	}
+	if err := s.userRepo.SetBanned(ctx, userID, true); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	// Banned first, so a refresh racing the revocation is already refused
+	return s.revocationSvc.RevokeUser(ctx, userID)
+}
+
+func (s *roleService) Unban(ctx context.Context, userID uuid.UUID) error {
+	if err := s.userRepo.SetBanned(ctx, userID, false); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *roleService) HasPermission(ctx context.Context, userID uuid.UUID, permission string) (bool, error) {
+	permissions, err := s.repo.FindUserPermissions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(permissions, enum.PermissionEnum(permission)), nil
+}
+
+// NormalizeRoleCode lowercases and trims a role code, codes are compared case-insensitively
+func NormalizeRoleCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// normalizePermissions rejects unknown codes and drops duplicates
+func normalizePermissions(permissions []enum.PermissionEnum) ([]enum.PermissionEnum, error) {
+	normalized := make([]enum.PermissionEnum, 0, len(permissions))
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPermission, permission)
+		}
+		if !slices.Contains(normalized, permission) {
+			normalized = append(normalized, permission)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// ensureCodeFree fails when another role than self already uses the code
+func (s *roleService) ensureCodeFree(ctx context.Context, code string, self uuid.UUID) error {
+	existing, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("find role: %w", err)
+	}
+	if existing.ID != self {
+		return ErrRoleExists
+	}
+	return nil
+}
+
+func (s *roleService) find(ctx context.Context, id uuid.UUID) (*entity.Role, error) {
+	role, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("find role: %w", err)
+	}
+	return role, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"api-stack-underflow/internal/common/enum"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/redis"
+	questionRepository "api-stack-underflow/internal/repository/question"
+
+	"github.com/google/uuid"
+)
+
+const (
+	channelPrefix = "questions:stream:"
+	// listenerBuffer is how many events a slow client may lag behind before it is dropped
+	listenerBuffer = 16
+)
+
+// ErrQuestionNotFound mirrors the question service error, importing it would be a cycle
+var ErrQuestionNotFound = errors.New("question not found")
+
+type IStreamService interface {
+	// Publish pushes a live update to every subscriber of the question on any
+	// instance. Failures are logged, a missed update must not fail the caller.
+	Publish(ctx context.Context, questionID uuid.UUID, eventType enum.QuestionEventEnum, data any)
+	// Subscribe returns the events of one question until cancel is called. The
+	// channel is closed when the client falls too far behind or the server stops.
+	Subscribe(ctx context.Context, questionID uuid.UUID) (<-chan entity.QuestionEvent, func(), error)
+	// Run relays Redis pub/sub messages to local subscribers until ctx is cancelled
+	Run(ctx context.Context)
+}
+
+type listener chan entity.QuestionEvent
+
+type streamService struct {
+	questionRepo questionRepository.IQuestionRepository
+	cache        *redis.Client
+
+	mu        sync.Mutex
+	listeners map[uuid.UUID]map[listener]struct{}
+	closed    bool
+}
+
+// NewStreamService takes an optional cache, without Redis events only reach
+// subscribers connected to the same instance
+func NewStreamService(questionRepo questionRepository.IQuestionRepository, cache *redis.Client) IStreamService {
+	return &streamService{
+		questionRepo: questionRepo,
+		cache:        cache,
+		listeners:    make(map[uuid.UUID]map[listener]struct{}),
+	}
+}
+
+func (s *streamService) Publish(ctx context.Context, questionID uuid.UUID, eventType enum.QuestionEventEnum, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		logger.Log.Warn().Err(err).Str("event", eventType.ToString()).Msg("Failed to encode question event")
+		return
+	}
+
+	event := entity.QuestionEvent{
+		ID:         uuid.New(),
+		Type:       eventType,
+		QuestionID: questionID,
+		Data:       payload,
+		CreatedAt:  time.Now(),
+	}
+
+	if s.cache != nil {
+		// Run delivers it back to this instance along with every other one
+		err := s.cache.Publish(channelPrefix+questionID.String(), event)
+		if err == nil {
+			return
+		}
+		logger.Log.Warn().Err(err).Str("question_id", questionID.String()).Msg("Failed to publish question event, delivering locally only")
+	}
+	s.deliver(event)
+}
+
+func (s *streamService) Subscribe(ctx context.Context, questionID uuid.UUID) (<-chan entity.QuestionEvent, func(), error) {
+	question, err := s.questionRepo.FindByID(ctx, questionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrQuestionNotFound
+		}
+		return nil, nil, fmt.Errorf("find question: %w", err)
+	}
+	if question.IsHidden {
+		return nil, nil, ErrQuestionNotFound
+	}
+
+	l := make(listener, listenerBuffer)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		close(l)
+		return l, func() {}, nil
+	}
+	if s.listeners[questionID] == nil {
+		s.listeners[questionID] = make(map[listener]struct{})
+	}
+	s.listeners[questionID][l] = struct{}{}
+
+	cancel := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.remove(questionID, l)
+	}
+	return l, cancel, nil
+}
+
+func (s *streamService) Run(ctx context.Context) {
+	defer s.closeAll()
+
+	if s.cache == nil {
+		<-ctx.Done()
+		return
+	}
+
+	pubsub, err := s.cache.PSubscribe(ctx, channelPrefix+"*")
+	if err != nil {
+		logger.Log.Error().Err(err).Msg("Failed to subscribe to question events, streams only see local updates")
+		<-ctx.Done()
+		return
+	}
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var event entity.QuestionEvent
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				logger.Log.Warn().Err(err).Str("channel", msg.Channel).Msg("Skipping malformed question event")
+				continue
+			}
+			if event.QuestionID.String() != strings.TrimPrefix(msg.Channel, channelPrefix) {
+				continue
+			}
+			s.deliver(event)
+		}
+	}
+}
+
+// deliver never blocks on a client, one that cannot keep up is disconnected
+// so its EventSource reconnects and reloads the page state
+func (s *streamService) deliver(event entity.QuestionEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for l := range s.listeners[event.QuestionID] {
+		select {
+		case l <- event:
+		default:
+			s.remove(event.QuestionID, l)
+		}
+	}
+}
+
+// remove expects s.mu to be held, removing twice is a no-op
+func (s *streamService) remove(questionID uuid.UUID, l listener) {
+	listeners, ok := s.listeners[questionID]
+	if !ok {
+		return
+	}
+	if _, ok := listeners[l]; !ok {
+		return
+	}
+
+	delete(listeners, l)
+	close(l)
+	if len(listeners) == 0 {
+		delete(s.listeners, questionID)
+	}
+}
+
+func (s *streamService) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for questionID, listeners := range s.listeners {
+		for l := range listeners {
+			s.remove(questionID, l)
+		}
+	}
+}
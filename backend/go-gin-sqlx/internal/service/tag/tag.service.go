@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/tag"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/pagination"
+	tagRepository "api-stack-underflow/internal/repository/tag"
+	reputationService "api-stack-underflow/internal/service/reputation"
+)
+
+const MaxTagsPerQuestion = 5
+
+var (
+	ErrTagNotFound    = errors.New("tag not found")
+	ErrTagForbidden   = errors.New("not allowed to manage tags")
+	ErrTagExists      = errors.New("tag already exists")
+	ErrSynonymExists  = errors.New("synonym already exists")
+	ErrInvalidTagName = errors.New("tag names must be 1-35 characters of a-z, 0-9, +, #, . or -")
+	ErrTooManyTags    = fmt.Errorf("a question can have at most %d tags", MaxTagsPerQuestion)
+)
+
+var tagNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+#.\-]{0,34}$`)
+
+type ITagService interface {
+	List(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[dto.TagResponse], error)
+	Get(ctx context.Context, name string) (*dto.TagDetailResponse, error)
+	Create(ctx context.Context, user *jwt.Claims, req dto.CreateTagRequest) (*dto.TagResponse, error)
+	// Update and AddSynonym change every question using the tag and need the manage tags privilege
+	Update(ctx context.Context, user *jwt.Claims, name string, req dto.UpdateTagRequest) (*dto.TagResponse, error)
+	// AddSynonym merges the synonym into the tag when it already is a tag of its own
+	AddSynonym(ctx context.Context, user *jwt.Claims, name string, req dto.CreateSynonymRequest) (*dto.TagDetailResponse, error)
+	// Canonicalize normalizes, validates, de-duplicates and resolves synonyms of question tags
+	Canonicalize(ctx context.Context, names []string) ([]string, error)
+}
+
+type tagService struct {
+	repo          tagRepository.ITagRepository
+	reputationSvc reputationService.IReputationService
+}
+
+func NewTagService(repo tagRepository.ITagRepository, reputationSvc reputationService.IReputationService) ITagService {
+	return &tagService{repo: repo, reputationSvc: reputationSvc}
+}
+
+// TagPaginationConfig describes the search and sorts accepted by the tag list
+func TagPaginationConfig() pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithSearch("q", pagination.FieldConfig{Field: "name", TableAlias: "t"}).
+		WithSort("name", pagination.WithSortTableAlias("t")).
+		WithSort("usage_count").
+		WithSort("created_at", pagination.WithSortTableAlias("t")).
+		SetDefaultSort("usage_count")
+	return config
+}
+
+// NormalizeTagName lowercases the name and turns inner whitespace into hyphens
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "-")
+}
+
+func IsValidTagName(name string) bool {
+	return tagNamePattern.MatchString(name)
+}
+
+func (s *tagService) List(ctx context.Context, p *pagination.Pagination) (pagination.PaginatedResponse[dto.TagResponse], error) {
+	result, err := s.repo.FindAll(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.TagResponse]{}, fmt.Errorf("list tags: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewTagResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+func (s *tagService) Get(ctx context.Context, name string) (*dto.TagDetailResponse, error) {
+	name = NormalizeTagName(name)
+
+	// A synonym lands on its canonical tag page
+	resolved, err := s.repo.ResolveSynonyms(ctx, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	if canonical, ok := resolved[name]; ok {
+		name = canonical
+	}
+
+	tag, err := s.find(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, tag)
+}
+
+func (s *tagService) Create(ctx context.Context, user *jwt.Claims, req dto.CreateTagRequest) (*dto.TagResponse, error) {
+	name := NormalizeTagName(req.Name)
+	if !IsValidTagName(name) {
+		return nil, ErrInvalidTagName
+	}
+
+	if _, err := s.repo.FindByName(ctx, name); err == nil {
+		return nil, ErrTagExists
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("find tag: %w", err)
+	}
+	if exists, err := s.repo.SynonymExists(ctx, name); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, ErrSynonymExists
+	}
+
+	tag := &entity.Tag{
+		Name:        name,
+		Description: req.Description,
+		CreatedBy:   &user.UserID,
+	}
+	if err := s.repo.Create(ctx, tag); err != nil {
+		return nil, fmt.Errorf("create tag: %w", err)
+	}
+	response := dto.NewTagResponse(*tag)
+	return &response, nil
+}
+
+func (s *tagService) Update(ctx context.Context, user *jwt.Claims, name string, req dto.UpdateTagRequest) (*dto.TagResponse, error) {
+	if err := s.require(ctx, user); err != nil {
+		return nil, err
+	}
+	tag, err := s.find(ctx, NormalizeTagName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	tag.Description = req.Description
+	if err := s.repo.UpdateDescription(ctx, tag); err != nil {
+		return nil, fmt.Errorf("update tag: %w", err)
+	}
+	response := dto.NewTagResponse(*tag)
+	return &response, nil
+}
+
+func (s *tagService) AddSynonym(ctx context.Context, user *jwt.Claims, name string, req dto.CreateSynonymRequest) (*dto.TagDetailResponse, error) {
+	if err := s.require(ctx, user); err != nil {
+		return nil, err
+	}
+	tag, err := s.find(ctx, NormalizeTagName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	synonym := NormalizeTagName(req.Synonym)
+	if !IsValidTagName(synonym) {
+		return nil, ErrInvalidTagName
+	}
+	if exists, err := s.repo.SynonymExists(ctx, synonym); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, ErrSynonymExists
+	}
+
+	// Tags are created as questions use them, so the synonym is often one already
+	existing, err := s.repo.FindByName(ctx, synonym)
+	switch {
+	case err == nil:
+		if existing.ID == tag.ID {
+			return nil, ErrTagExists
+		}
+		if err := s.repo.Merge(ctx, existing, tag); err != nil {
+			return nil, fmt.Errorf("merge tag: %w", err)
+		}
+		return s.Get(ctx, tag.Name)
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("find tag: %w", err)
+	}
+
+	if err := s.repo.CreateSynonym(ctx, &entity.TagSynonym{Name: synonym, TagID: tag.ID}); err != nil {
+		return nil, fmt.Errorf("create synonym: %w", err)
+	}
+	return s.detail(ctx, tag)
+}
+
+func (s *tagService) Canonicalize(ctx context.Context, names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if !IsValidTagName(name) {
+			return nil, ErrInvalidTagName
+		}
+		normalized = append(normalized, name)
+	}
+
+	resolved, err := s.repo.ResolveSynonyms(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := make([]string, 0, len(normalized))
+	seen := make(map[string]struct{}, len(normalized))
+	for _, name := range normalized {
+		if target, ok := resolved[name]; ok {
+			name = target
+		}
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		seen[name] = struct{}{}
+		canonical = append(canonical, name)
+	}
+
+	if len(canonical) > MaxTagsPerQuestion {
+		return nil, ErrTooManyTags
+	}
+	return canonical, nil
+}
+
+func (s *tagService) require(ctx context.Context, user *jwt.Claims) error {
+	if err := s.reputationSvc.Require(ctx, user.UserID, enum.PRIVILEGE_MANAGE_TAGS); err != nil {
+		if errors.Is(err, reputationService.ErrInsufficientReputation) {
+			return fmt.Errorf("%w: %w", ErrTagForbidden, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *tagService) find(ctx context.Context, name string) (*entity.Tag, error) {
+	tag, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTagNotFound
+		}
+		return nil, fmt.Errorf("find tag: %w", err)
+	}
+	return tag, nil
+}
+
+func (s *tagService) detail(ctx context.Context, tag *entity.Tag) (*dto.TagDetailResponse, error) {
+	synonyms, err := s.repo.FindSynonyms(ctx, tag.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TagDetailResponse{
+		TagResponse: dto.NewTagResponse(*tag),
+		Synonyms:    synonyms,
+	}, nil
+}
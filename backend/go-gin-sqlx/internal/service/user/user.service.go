@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	dto "api-stack-underflow/internal/dto/user"
+	"api-stack-underflow/internal/entity"
+	database "api-stack-underflow/internal/pkg/db"
+	"api-stack-underflow/internal/pkg/jwt"
+	"api-stack-underflow/internal/pkg/pagination"
+	userRepository "api-stack-underflow/internal/repository/user"
+)
+
+var (
+	ErrUserNotFound  = errors.New("user not found")
+	ErrUsernameTaken = errors.New("username is already taken")
+)
+
+type IUserService interface {
+	Profile(ctx context.Context, username string) (*dto.UserProfileResponse, error)
+	Activity(ctx context.Context, username string, p *pagination.Pagination) (pagination.PaginatedResponse[dto.ActivityResponse], error)
+	UpdateProfile(ctx context.Context, user *jwt.Claims, req dto.UpdateProfileRequest) (*dto.UpdateProfileResponse, error)
+}
+
+type userService struct {
+	repo userRepository.IUserRepository
+	auth *jwt.Manager
+}
+
+func NewUserService(repo userRepository.IUserRepository, auth *jwt.Manager) IUserService {
+	return &userService{repo: repo, auth: auth}
+}
+
+// ActivityPaginationConfig describes the activity feed; Activity scopes it to one user
+func ActivityPaginationConfig() pagination.PaginationConfig {
+	config := pagination.NewDefaultPaginationConfig()
+	config.
+		WithFilter("user_id", pagination.WithDataType("uuid"), pagination.WithTableAlias("act")).
+		WithFilter("post_type", pagination.WithDataType("string"), pagination.WithOperator("="), pagination.WithTableAlias("act")).
+		WithSort("created_at", pagination.WithSortTableAlias("act")).
+		WithSort("score", pagination.WithSortTableAlias("act")).
+		SetDefaultSort("created_at", pagination.WithSortTableAlias("act"))
+	return config
+}
+
+func (s *userService) Profile(ctx context.Context, username string) (*dto.UserProfileResponse, error) {
+	user, err := s.find(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.NewUserProfileResponse(*user)
+	return &response, nil
+}
+
+func (s *userService) Activity(ctx context.Context, username string, p *pagination.Pagination) (pagination.PaginatedResponse[dto.ActivityResponse], error) {
+	user, err := s.find(ctx, username)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.ActivityResponse]{}, err
+	}
+
+	// The path already scopes the feed, a query string user_id must not widen it
+	delete(p.Filters, "user_id")
+	p.PaginationConfig.DefaultFilter["user_id"] = pagination.DefaultFilterField{
+		Value:    user.ID.String(),
+		Operator: "=",
+	}
+
+	result, err := s.repo.FindActivity(ctx, p)
+	if err != nil {
+		return pagination.PaginatedResponse[dto.ActivityResponse]{}, fmt.Errorf("user activity: %w", err)
+	}
+	return pagination.NewPaginatedResponse(dto.NewActivityResponses(result.Data), result.Total, result.Page, result.PageSize), nil
+}
+
+func (s *userService) UpdateProfile(ctx context.Context, claims *jwt.Claims, req dto.UpdateProfileRequest) (*dto.UpdateProfileResponse, error) {
+	user, err := s.repo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	renamed := false
+	if req.Username != nil && *req.Username != user.Username {
+		// Changing only the case of your own name is not taken by someone else
+		if !strings.EqualFold(*req.Username, user.Username) {
+			exists, err := s.repo.ExistsByUsername(ctx, *req.Username)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, ErrUsernameTaken
+			}
+		}
+		user.Username = *req.Username
+		renamed = true
+	}
+	if req.Bio != nil {
+		user.Bio = *req.Bio
+	}
+	if req.AvatarURL != nil {
+		user.AvatarURL = req.AvatarURL
+		if *req.AvatarURL == "" {
+			user.AvatarURL = nil
+		}
+	}
+
+	if err := s.repo.UpdateProfile(ctx, user, renamed); err != nil {
+		// A concurrent signup or rename took the name after the check above
+		if database.IsUniqueViolation(err) {
+			return nil, ErrUsernameTaken
+		}
+		return nil, fmt.Errorf("update profile: %w", err)
+	}
+
+	response := &dto.UpdateProfileResponse{UserProfileResponse: dto.NewUserProfileResponse(*user)}
+	if renamed {
+		access, accessClaims, err := s.auth.GenerateToken(user.ID, user.Username, jwt.AccessToken)
+		if err != nil {
+			return nil, err
+		}
+		response.AccessToken = access
+		response.TokenType = "Bearer"
+		response.ExpiresAt = accessClaims.ExpiresAt.Format(time.RFC3339)
+	}
+	return response, nil
+}
+
+func (s *userService) find(ctx context.Context, username string) (*entity.User, error) {
+	user, err := s.repo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	return user, nil
+}
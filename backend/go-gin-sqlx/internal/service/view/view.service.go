@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/logger/v2"
+	"api-stack-underflow/internal/pkg/redis"
+	questionRepository "api-stack-underflow/internal/repository/question"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// FlushInterval is how often buffered views are written to the database
+	FlushInterval = time.Minute
+	// ViewWindow is how long repeated views of the same viewer count once
+	ViewWindow = 30 * time.Minute
+
+	seenKeyPrefix = "questions:views:seen:"
+	pendingKey    = "questions:views:pending"
+	// flushBatchSize bounds the number of questions updated per statement
+	flushBatchSize = 500
+)
+
+// botPattern matches the user agents of crawlers, link previews, monitors and
+// scripted clients, none of which are readers
+var botPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|scrape|archiver|preview|facebookexternalhit|headless|phantomjs|lighthouse|pingdom|uptime|monitor|curl|wget|httpclient|python-requests|python-urllib|go-http-client|okhttp|axios|node-fetch|java/`)
+
+type IViewService interface {
+	// Record counts a view of the question once per viewer and ViewWindow.
+	// Bots are ignored and failures are logged, a lost view must not fail the read.
+	Record(ctx context.Context, questionID uuid.UUID, viewer entity.Viewer)
+	// Flush writes the views buffered since the last flush to the database
+	Flush(ctx context.Context) error
+}
+
+type viewService struct {
+	questionRepo questionRepository.IQuestionRepository
+	cache        *redis.Client
+
+	// Views are buffered in memory when Redis is missing or failing
+	mu      sync.Mutex
+	seen    map[string]time.Time
+	pending map[uuid.UUID]int64
+}
+
+// NewViewService takes an optional cache. With Redis the dedup window and the
+// buffered counts are shared by every instance, without it they are per instance.
+func NewViewService(questionRepo questionRepository.IQuestionRepository, cache *redis.Client) IViewService {
+	return &viewService{
+		questionRepo: questionRepo,
+		cache:        cache,
+		seen:         make(map[string]time.Time),
+		pending:      make(map[uuid.UUID]int64),
+	}
+}
+
+// IsBot reports whether the user agent belongs to an automated client. An
+// empty user agent is treated as one, browsers always send it.
+func IsBot(userAgent string) bool {
+	userAgent = strings.TrimSpace(userAgent)
+	return userAgent == "" || botPattern.MatchString(userAgent)
+}
+
+func (s *viewService) Record(ctx context.Context, questionID uuid.UUID, viewer entity.Viewer) {
+	if IsBot(viewer.UserAgent) {
+		return
+	}
+	id, ok := viewerID(viewer)
+	if !ok {
+		return
+	}
+	key := seenKeyPrefix + questionID.String() + ":" + id
+
+	if s.cache != nil {
+		first, err := s.cache.SetNX(key, 1, ViewWindow)
+		if err == nil && !first {
+			return
+		}
+		if err == nil {
+			if err = s.cache.HIncrBy(pendingKey, questionID.String(), 1); err == nil {
+				return
+			}
+		}
+		logger.Log.Warn().Err(err).Str("question_id", questionID.String()).Msg("Failed to record view in redis, buffering locally")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if until, ok := s.seen[key]; ok && now.Before(until) {
+		return
+	}
+	s.seen[key] = now.Add(ViewWindow)
+	s.pending[questionID]++
+}
+
+// viewerID prefers the account so a user switching networks counts once
+func viewerID(viewer entity.Viewer) (string, bool) {
+	if viewer.UserID != nil {
+		return "user:" + viewer.UserID.String(), true
+	}
+	if viewer.IP == "" {
+		return "", false
+	}
+	return "ip:" + viewer.IP, true
+}
+
+func (s *viewService) Flush(ctx context.Context) error {
+	views := s.takeLocal()
+
+	if s.cache != nil {
+		fields, err := s.cache.TakeHash(pendingKey)
+		if err != nil {
+			logger.Log.Warn().Err(err).Msg("Failed to read buffered views from redis")
+		}
+		for field, value := range fields {
+			questionID, err := uuid.Parse(field)
+			if err != nil {
+				continue
+			}
+			count, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || count <= 0 {
+				continue
+			}
+			views[questionID] += count
+		}
+	}
+
+	batch := make(map[uuid.UUID]int64, min(len(views), flushBatchSize))
+	var failed error
+	write := func() {
+		if err := s.questionRepo.AddViews(ctx, batch); err != nil {
+			// Kept for the next flush instead of being dropped
+			s.restoreLocal(batch)
+			failed = err
+		}
+		batch = make(map[uuid.UUID]int64, flushBatchSize)
+	}
+	for questionID, count := range views {
+		batch[questionID] = count
+		if len(batch) == flushBatchSize {
+			write()
+		}
+	}
+	if len(batch) > 0 {
+		write()
+	}
+	if failed != nil {
+		return fmt.Errorf("flush views: %w", failed)
+	}
+	return nil
+}
+
+// takeLocal swaps out the local counts and forgets expired viewers
+func (s *viewService) takeLocal() map[uuid.UUID]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, until := range s.seen {
+		if !now.Before(until) {
+			delete(s.seen, key)
+		}
+	}
+
+	views := s.pending
+	s.pending = make(map[uuid.UUID]int64)
+	return views
+}
+
+func (s *viewService) restoreLocal(views map[uuid.UUID]int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for questionID, count := range views {
+		s.pending[questionID] += count
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"api-stack-underflow/internal/common/enum"
+	dto "api-stack-underflow/internal/dto/vote"
+	"api-stack-underflow/internal/entity"
+	"api-stack-underflow/internal/pkg/jwt"
+	answerRepository "api-stack-underflow/internal/repository/answer"
+	questionRepository "api-stack-underflow/internal/repository/question"
+	voteRepository "api-stack-underflow/internal/repository/vote"
+	answerService "api-stack-underflow/internal/service/answer"
+	badgeService "api-stack-underflow/internal/service/badge"
+	questionService "api-stack-underflow/internal/service/question"
+	reputationService "api-stack-underflow/internal/service/reputation"
+	streamService "api-stack-underflow/internal/service/stream"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrSelfVote = errors.New("you cannot vote on your own post")
+
+// VoteTarget identifies the post being voted on; AnswerID is nil for the question itself
+type VoteTarget struct {
+	QuestionID uuid.UUID
+	AnswerID   *uuid.UUID
+}
+
+type IVoteService interface {
+	Vote(ctx context.Context, user *jwt.Claims, target VoteTarget, direction enum.VoteDirectionEnum) (*dto.VoteResponse, error)
+	Retract(ctx context.Context, user *jwt.Claims, target VoteTarget) (*dto.VoteResponse, error)
+}
+
+type voteService struct {
+	repo          voteRepository.IVoteRepository
+	questionRepo  questionRepository.IQuestionRepository
+	answerRepo    answerRepository.IAnswerRepository
+	reputationSvc reputationService.IReputationService
+	streamSvc     streamService.IStreamService
+	badgeSvc      badgeService.IBadgeService
+}
+
+func NewVoteService(repo voteRepository.IVoteRepository, questionRepo questionRepository.IQuestionRepository, answerRepo answerRepository.IAnswerRepository, reputationSvc reputationService.IReputationService, streamSvc streamService.IStreamService, badgeSvc badgeService.IBadgeService) IVoteService {
+	return &voteService{repo: repo, questionRepo: questionRepo, answerRepo: answerRepo, reputationSvc: reputationSvc, streamSvc: streamSvc, badgeSvc: badgeSvc}
+}
+
+func (s *voteService) Vote(ctx context.Context, user *jwt.Claims, target VoteTarget, direction enum.VoteDirectionEnum) (*dto.VoteResponse, error) {
+	if direction == enum.VOTE_DOWN {
+		if err := s.reputationSvc.Require(ctx, user.UserID, enum.PRIVILEGE_DOWN_VOTE); err != nil {
+			return nil, err
+		}
+	}
+	return s.cast(ctx, user, target, direction.Value())
+}
+
+func (s *voteService) Retract(ctx context.Context, user *jwt.Claims, target VoteTarget) (*dto.VoteResponse, error) {
+	return s.cast(ctx, user, target, 0)
+}
+
+func (s *voteService) cast(ctx context.Context, user *jwt.Claims, target VoteTarget, value int) (*dto.VoteResponse, error) {
+	targetType, targetID, ownerID, err := s.resolve(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID == user.UserID {
+		return nil, ErrSelfVote
+	}
+
+	// Reputation moves in the same transaction as the vote and score
+	hook := func(ctx context.Context, tx *sqlx.Tx, previous int) error {
+		events := reputationService.VoteEvents(targetType, targetID, ownerID, user.UserID, previous, value)
+		return s.reputationSvc.Apply(ctx, tx, events)
+	}
+
+	score, err := s.repo.Cast(ctx, user.UserID, targetType, targetID, value, hook)
+	if err != nil {
+		return nil, fmt.Errorf("cast vote: %w", err)
+	}
+	s.streamSvc.Publish(ctx, target.QuestionID, enum.QUESTION_EVENT_SCORE_CHANGED, dto.ScoreResponse{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Score:      score,
+	})
+	s.badgeSvc.Publish(ctx, entity.DomainEvent{
+		Event:    enum.DOMAIN_EVENT_VOTE_CAST,
+		ActorID:  user.UserID,
+		OwnerID:  &ownerID,
+		PostType: postType(targetType),
+		PostID:   targetID,
+	})
+	return &dto.VoteResponse{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Score:      score,
+		UserVote:   value,
+	}, nil
+}
+
+// resolve returns the target type, id and author of the voted post
+func (s *voteService) resolve(ctx context.Context, target VoteTarget) (enum.VoteTargetEnum, uuid.UUID, uuid.UUID, error) {
+	if target.AnswerID == nil {
+		question, err := s.questionRepo.FindByID(ctx, target.QuestionID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", uuid.Nil, uuid.Nil, questionService.ErrQuestionNotFound
+			}
+			return "", uuid.Nil, uuid.Nil, fmt.Errorf("find question: %w", err)
+		}
+		return enum.VOTE_TARGET_QUESTION, question.ID, question.UserID, nil
+	}
+
+	answer, err := s.answerRepo.FindByID(ctx, *target.AnswerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", uuid.Nil, uuid.Nil, answerService.ErrAnswerNotFound
+		}
+		return "", uuid.Nil, uuid.Nil, fmt.Errorf("find answer: %w", err)
+	}
+	if answer.QuestionID != target.QuestionID {
+		return "", uuid.Nil, uuid.Nil, answerService.ErrAnswerNotFound
+	}
+	return enum.VOTE_TARGET_ANSWER, answer.ID, answer.UserID, nil
+}
+
+func postType(target enum.VoteTargetEnum) enum.PostTypeEnum {
+	if target == enum.VOTE_TARGET_ANSWER {
+		return enum.POST_TYPE_ANSWER
+	}
+	return enum.POST_TYPE_QUESTION
+}
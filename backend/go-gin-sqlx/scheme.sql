@@ -1,11 +1,21 @@
 -- StackUnderflow Database Schema
 -- Q&A Platform like Stack Overflow
 
+-- Trigram similarity for related questions
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 -- Users table
 CREATE TABLE IF NOT EXISTS su_users (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     username VARCHAR(100) NOT NULL UNIQUE,
     password VARCHAR(255) NOT NULL,
+    bio TEXT NOT NULL DEFAULT '',
+    avatar_url VARCHAR(500),
+    reputation INTEGER NOT NULL DEFAULT 1,
+    -- Every token of the user issued up to this instant is revoked
+    tokens_revoked_before TIMESTAMP WITH TIME ZONE,
+    -- Banned users cannot sign in or refresh their tokens
+    banned_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -18,6 +28,16 @@ CREATE TABLE IF NOT EXISTS su_questions (
     status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'answered', 'closed')),
     user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
     username VARCHAR(100) NOT NULL,
+    score INTEGER NOT NULL DEFAULT 0,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    view_count INTEGER NOT NULL DEFAULT 0,
+    bounty_amount INTEGER NOT NULL DEFAULT 0,
+    has_bounty BOOLEAN GENERATED ALWAYS AS (bounty_amount > 0) STORED,
+    search_vector TSVECTOR,
+    close_reason VARCHAR(20) CHECK (close_reason IN ('duplicate', 'off_topic', 'unclear')),
+    duplicate_of UUID REFERENCES su_questions(id) ON DELETE SET NULL,
+    closed_at TIMESTAMP WITH TIME ZONE,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -29,10 +49,248 @@ CREATE TABLE IF NOT EXISTS su_comments (
     user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
     username VARCHAR(100) NOT NULL,
     content TEXT NOT NULL,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Revoked tokens (logout); rows can be purged once expires_at has passed
+CREATE TABLE IF NOT EXISTS su_revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Answers table
+CREATE TABLE IF NOT EXISTS su_answers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    question_id UUID NOT NULL REFERENCES su_questions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    username VARCHAR(100) NOT NULL,
+    content TEXT NOT NULL,
+    is_accepted BOOLEAN NOT NULL DEFAULT FALSE,
+    score INTEGER NOT NULL DEFAULT 0,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Votes table, one vote per user per question or answer
+CREATE TABLE IF NOT EXISTS su_votes (
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('question', 'answer')),
+    target_id UUID NOT NULL,
+    value SMALLINT NOT NULL CHECK (value IN (-1, 1)),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, target_type, target_id)
+);
+
+-- Tags table; su_questions.tags mirrors su_question_tags for array filtering
+CREATE TABLE IF NOT EXISTS su_tags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(35) NOT NULL UNIQUE CHECK (name ~ '^[a-z0-9][a-z0-9+#.\-]*$'),
+    description TEXT NOT NULL DEFAULT '',
+    created_by UUID REFERENCES su_users(id) ON DELETE SET NULL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE IF NOT EXISTS su_tag_synonyms (
+    name VARCHAR(35) PRIMARY KEY,
+    tag_id UUID NOT NULL REFERENCES su_tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS su_question_tags (
+    question_id UUID NOT NULL REFERENCES su_questions(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES su_tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (question_id, tag_id)
+);
+
+-- Reputation ledger; su_users.reputation is 1 plus the sum of a user's amounts
+CREATE TABLE IF NOT EXISTS su_reputation_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    post_type VARCHAR(20) NOT NULL CHECK (post_type IN ('question', 'answer')),
+    post_id UUID NOT NULL,
+    actor_id UUID REFERENCES su_users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Edit history of questions, answers and comments; payload is helper.BuildHistoryPayload output
+CREATE TABLE IF NOT EXISTS su_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    question_id UUID NOT NULL REFERENCES su_questions(id) ON DELETE CASCADE,
+    post_type VARCHAR(20) NOT NULL CHECK (post_type IN ('question', 'answer', 'comment')),
+    post_id UUID NOT NULL,
+    user_id UUID REFERENCES su_users(id) ON DELETE SET NULL,
+    username VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Close and reopen votes; resolved rows stay as the audit trail
+CREATE TABLE IF NOT EXISTS su_close_votes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    question_id UUID NOT NULL REFERENCES su_questions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    username VARCHAR(100) NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('close', 'reopen')),
+    reason VARCHAR(20) CHECK (reason IN ('duplicate', 'off_topic', 'unclear')),
+    duplicate_of UUID REFERENCES su_questions(id) ON DELETE SET NULL,
+    resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((action = 'close') = (reason IS NOT NULL))
+);
+
+-- Flags raised by users on posts, reviewed by moderators
+CREATE TABLE IF NOT EXISTS su_flags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_type VARCHAR(20) NOT NULL CHECK (post_type IN ('question', 'answer', 'comment')),
+    post_id UUID NOT NULL,
+    question_id UUID NOT NULL REFERENCES su_questions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    username VARCHAR(100) NOT NULL,
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('spam', 'rude', 'needs_improvement')),
+    note TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'dismissed')),
+    reviewed_by UUID REFERENCES su_users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Moderation actions; moderator_id is NULL for automatic hides, payload is helper.BuildHistoryPayload output
+CREATE TABLE IF NOT EXISTS su_moderation_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    action VARCHAR(20) NOT NULL CHECK (action IN ('approve', 'dismiss', 'auto_hide')),
+    post_type VARCHAR(20) NOT NULL CHECK (post_type IN ('question', 'answer', 'comment')),
+    post_id UUID NOT NULL,
+    flag_id UUID REFERENCES su_flags(id) ON DELETE SET NULL,
+    moderator_id UUID REFERENCES su_users(id) ON DELETE SET NULL,
+    payload JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Users following a question get notified when it changes
+CREATE TABLE IF NOT EXISTS su_question_follows (
+    question_id UUID NOT NULL REFERENCES su_questions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (question_id, user_id)
+);
+
+-- Notification inbox; one row per recipient of an event, written by the queue consumer
+CREATE TABLE IF NOT EXISTS su_notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('answer', 'comment', 'mention', 'followed_question')),
+    event VARCHAR(20) NOT NULL CHECK (event IN ('answer_created', 'comment_created', 'question_updated', 'question_closed', 'question_reopened')),
+    question_id UUID NOT NULL REFERENCES su_questions(id) ON DELETE CASCADE,
+    post_type VARCHAR(20) NOT NULL CHECK (post_type IN ('question', 'answer', 'comment')),
+    post_id UUID NOT NULL,
+    actor_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    actor_username VARCHAR(100) NOT NULL,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, event_id)
+);
+
+-- Badges awarded by the rule catalog in the badge service. post_id is set for
+-- badges earned once per post; the partial unique indexes make awarding idempotent
+CREATE TABLE IF NOT EXISTS su_user_badges (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    badge VARCHAR(50) NOT NULL,
+    post_id UUID,
+    awarded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Reputation offered on a question. The amount leaves the sponsor when the
+-- bounty starts and goes to an answer, or back to the sponsor, when it ends.
+CREATE TABLE IF NOT EXISTS su_bounties (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    question_id UUID NOT NULL REFERENCES su_questions(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'awarded', 'refunded')),
+    answer_id UUID REFERENCES su_answers(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Issued refresh tokens, one family per login. A rotated token keeps used_at and
+-- replaced_by, presenting it again revokes the whole family.
+CREATE TABLE IF NOT EXISTS su_refresh_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    family_id UUID NOT NULL,
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    replaced_by VARCHAR(64),
+    used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Permissions mirror enum.PermissionEnum, roles grant them to the users they are assigned to
+CREATE TABLE IF NOT EXISTS su_permissions (
+    code VARCHAR(100) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS su_roles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS su_role_permissions (
+    role_id UUID NOT NULL REFERENCES su_roles(id) ON DELETE CASCADE,
+    permission_code VARCHAR(100) NOT NULL REFERENCES su_permissions(code) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_code)
+);
+
+-- An inactive role stays assigned but grants nothing
+CREATE TABLE IF NOT EXISTS su_user_roles (
+    user_id UUID NOT NULL REFERENCES su_users(id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES su_roles(id) ON DELETE CASCADE,
+    assigned_by UUID REFERENCES su_users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id)
+);
+
+-- Columns added after the first release. CREATE TABLE IF NOT EXISTS leaves an
+-- existing table untouched, so a database created from an earlier version of
+-- this script gets them here; re-running the script is a no-op.
+ALTER TABLE su_users ADD COLUMN IF NOT EXISTS bio TEXT NOT NULL DEFAULT '';
+ALTER TABLE su_users ADD COLUMN IF NOT EXISTS avatar_url VARCHAR(500);
+ALTER TABLE su_users ADD COLUMN IF NOT EXISTS reputation INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE su_users ADD COLUMN IF NOT EXISTS tokens_revoked_before TIMESTAMP WITH TIME ZONE;
+ALTER TABLE su_users ADD COLUMN IF NOT EXISTS banned_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS view_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS bounty_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS has_bounty BOOLEAN GENERATED ALWAYS AS (bounty_amount > 0) STORED;
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS close_reason VARCHAR(20) CHECK (close_reason IN ('duplicate', 'off_topic', 'unclear'));
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS duplicate_of UUID REFERENCES su_questions(id) ON DELETE SET NULL;
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE su_questions ADD COLUMN IF NOT EXISTS is_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE su_comments ADD COLUMN IF NOT EXISTS is_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
 -- Indexes for better query performance
 CREATE INDEX IF NOT EXISTS idx_su_questions_user_id ON su_questions(user_id);
 CREATE INDEX IF NOT EXISTS idx_su_questions_status ON su_questions(status);
@@ -43,6 +301,58 @@ CREATE INDEX IF NOT EXISTS idx_su_comments_question_id ON su_comments(question_i
 CREATE INDEX IF NOT EXISTS idx_su_comments_user_id ON su_comments(user_id);
 CREATE INDEX IF NOT EXISTS idx_su_comments_created_at ON su_comments(created_at DESC);
 
+CREATE INDEX IF NOT EXISTS idx_su_revoked_tokens_expires_at ON su_revoked_tokens(expires_at);
+
+CREATE INDEX IF NOT EXISTS idx_su_answers_question_id ON su_answers(question_id);
+CREATE INDEX IF NOT EXISTS idx_su_answers_user_id ON su_answers(user_id);
+-- At most one accepted answer per question
+CREATE UNIQUE INDEX IF NOT EXISTS uq_su_answers_accepted ON su_answers(question_id) WHERE is_accepted;
+
+CREATE INDEX IF NOT EXISTS idx_su_votes_target ON su_votes(target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_su_questions_score ON su_questions(score DESC);
+CREATE INDEX IF NOT EXISTS idx_su_questions_view_count ON su_questions(view_count DESC);
+
+CREATE INDEX IF NOT EXISTS idx_su_questions_tags ON su_questions USING gin(tags);
+CREATE INDEX IF NOT EXISTS idx_su_question_tags_tag_id ON su_question_tags(tag_id);
+CREATE INDEX IF NOT EXISTS idx_su_tag_synonyms_tag_id ON su_tag_synonyms(tag_id);
+
+CREATE INDEX IF NOT EXISTS idx_su_reputation_events_user_id ON su_reputation_events(user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_su_revisions_question_id ON su_revisions(question_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_su_revisions_post ON su_revisions(post_type, post_id, created_at);
+
+CREATE INDEX IF NOT EXISTS idx_su_questions_search_vector ON su_questions USING gin(search_vector);
+
+CREATE INDEX IF NOT EXISTS idx_su_questions_title_trgm ON su_questions USING gin(title gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_su_close_votes_question_id ON su_close_votes(question_id, created_at DESC);
+-- One pending vote per user and action
+CREATE UNIQUE INDEX IF NOT EXISTS uq_su_close_votes_pending ON su_close_votes(question_id, user_id, action) WHERE NOT resolved;
+
+CREATE INDEX IF NOT EXISTS idx_su_flags_status ON su_flags(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_su_flags_post ON su_flags(post_type, post_id);
+-- One pending flag per user and post
+CREATE UNIQUE INDEX IF NOT EXISTS uq_su_flags_pending ON su_flags(post_type, post_id, user_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_su_moderation_logs_created_at ON su_moderation_logs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_su_moderation_logs_post ON su_moderation_logs(post_type, post_id);
+
+CREATE INDEX IF NOT EXISTS idx_su_question_follows_user_id ON su_question_follows(user_id);
+CREATE INDEX IF NOT EXISTS idx_su_notifications_user_id ON su_notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_su_notifications_unread ON su_notifications(user_id, type) WHERE NOT is_read;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_su_user_badges_once ON su_user_badges(user_id, badge) WHERE post_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_su_user_badges_post ON su_user_badges(user_id, badge, post_id) WHERE post_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_su_bounties_question_id ON su_bounties(question_id, created_at DESC);
+-- One active bounty per question, the expiry job scans the active ones by deadline
+CREATE UNIQUE INDEX IF NOT EXISTS uq_su_bounties_active ON su_bounties(question_id) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_su_bounties_expires_at ON su_bounties(expires_at) WHERE status = 'active';
+
+CREATE INDEX IF NOT EXISTS idx_su_refresh_tokens_family_id ON su_refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_su_refresh_tokens_expires_at ON su_refresh_tokens(expires_at);
+
+CREATE INDEX IF NOT EXISTS idx_su_user_roles_role_id ON su_user_roles(role_id);
+
 -- Function to update updated_at timestamp
 CREATE OR REPLACE FUNCTION update_updated_at_column()
 RETURNS TRIGGER AS $$
@@ -52,16 +362,119 @@ BEGIN
 END;
 $$ language 'plpgsql';
 
--- Triggers for updated_at
+-- A rename rewrites the denormalized username of every post of the user, the
+-- view counter is flushed in batches and the search document is rebuilt when
+-- comments change. Votes move the score, bounties set bounty_amount (and the
+-- generated has_bounty) and moderation toggles is_hidden. None is an edit of
+-- the post so all keep its updated_at
+CREATE OR REPLACE FUNCTION update_post_updated_at_column()
+RETURNS TRIGGER AS $$
+DECLARE
+    ignored TEXT[] := ARRAY['username', 'view_count', 'search_vector', 'score', 'bounty_amount', 'has_bounty', 'is_hidden'];
+BEGIN
+    IF (to_jsonb(NEW) - ignored) = (to_jsonb(OLD) - ignored) THEN
+        RETURN NEW;
+    END IF;
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Triggers for updated_at, dropped first so a re-run attaches the current functions
+DROP TRIGGER IF EXISTS update_su_users_updated_at ON su_users;
 CREATE TRIGGER update_su_users_updated_at BEFORE UPDATE ON su_users
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_su_questions_updated_at ON su_questions;
 CREATE TRIGGER update_su_questions_updated_at BEFORE UPDATE ON su_questions
-    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+    FOR EACH ROW EXECUTE FUNCTION update_post_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_su_comments_updated_at ON su_comments;
 CREATE TRIGGER update_su_comments_updated_at BEFORE UPDATE ON su_comments
+    FOR EACH ROW EXECUTE FUNCTION update_post_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_su_answers_updated_at ON su_answers;
+CREATE TRIGGER update_su_answers_updated_at BEFORE UPDATE ON su_answers
+    FOR EACH ROW EXECUTE FUNCTION update_post_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_su_votes_updated_at ON su_votes;
+CREATE TRIGGER update_su_votes_updated_at BEFORE UPDATE ON su_votes
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_su_tags_updated_at ON su_tags;
+CREATE TRIGGER update_su_tags_updated_at BEFORE UPDATE ON su_tags
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+DROP TRIGGER IF EXISTS update_su_roles_updated_at ON su_roles;
+CREATE TRIGGER update_su_roles_updated_at BEFORE UPDATE ON su_roles
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+-- Full-text document of a question: title (A), description (B) and its comments (C)
+CREATE OR REPLACE FUNCTION su_questions_search_vector()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(
+            (SELECT string_agg(content, ' ') FROM su_comments WHERE question_id = NEW.id), '')), 'C');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS su_questions_search_vector ON su_questions;
+CREATE TRIGGER su_questions_search_vector BEFORE INSERT OR UPDATE OF title, description, search_vector ON su_questions
+    FOR EACH ROW EXECUTE FUNCTION su_questions_search_vector();
+
+-- Builds the document of questions written before the column existed
+UPDATE su_questions SET search_vector = NULL WHERE search_vector IS NULL;
+
+-- Comment changes rebuild the search document of their question
+CREATE OR REPLACE FUNCTION su_comments_refresh_question_search()
+RETURNS TRIGGER AS $$
+DECLARE
+    target_question_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target_question_id := OLD.question_id;
+    ELSE
+        target_question_id := NEW.question_id;
+    END IF;
+
+    UPDATE su_questions SET search_vector = NULL WHERE id = target_question_id;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS su_comments_refresh_question_search ON su_comments;
+CREATE TRIGGER su_comments_refresh_question_search AFTER INSERT OR UPDATE OF content OR DELETE ON su_comments
+    FOR EACH ROW EXECUTE FUNCTION su_comments_refresh_question_search();
+
+-- Unified activity feed of a user, hidden posts and posts under hidden questions are left out
+CREATE OR REPLACE VIEW su_user_activity AS
+    SELECT 'question' AS post_type, q.id AS post_id, q.id AS question_id, q.title AS question_title,
+           q.user_id, q.description AS body, q.score, q.created_at
+    FROM su_questions q
+    WHERE NOT q.is_hidden
+    UNION ALL
+    SELECT 'answer', a.id, q.id, q.title, a.user_id, a.content, a.score, a.created_at
+    FROM su_answers a
+    JOIN su_questions q ON q.id = a.question_id
+    WHERE NOT a.is_hidden AND NOT q.is_hidden
+    UNION ALL
+    SELECT 'comment', c.id, q.id, q.title, c.user_id, c.content, 0, c.created_at
+    FROM su_comments c
+    JOIN su_questions q ON q.id = c.question_id
+    WHERE NOT c.is_hidden AND NOT q.is_hidden;
+
+-- Revisions of posts that are not hidden, the public edit history
+CREATE OR REPLACE VIEW su_visible_revisions AS
+    SELECT rv.*
+    FROM su_revisions rv
+    WHERE NOT EXISTS (SELECT 1 FROM su_questions q WHERE q.id = rv.question_id AND q.is_hidden)
+      AND NOT EXISTS (SELECT 1 FROM su_answers a WHERE rv.post_type = 'answer' AND a.id = rv.post_id AND a.is_hidden)
+      AND NOT EXISTS (SELECT 1 FROM su_comments c WHERE rv.post_type = 'comment' AND c.id = rv.post_id AND c.is_hidden);
+
 -- Insert sample data
 INSERT INTO su_users (id, username, password) VALUES
     ('550e8400-e29b-41d4-a716-446655440001', 'dev_master', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZRGdjGj/n3.uPuxQJ2B5p5F5F5F5F'),
@@ -69,6 +482,38 @@ INSERT INTO su_users (id, username, password) VALUES
     ('550e8400-e29b-41d4-a716-446655440003', 'js_learner', '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZRGdjGj/n3.uPuxQJ2B5p5F5F5F5F')
 ON CONFLICT (username) DO NOTHING;
 
+-- Permissions and the built-in roles, dev_master administers the sample data
+INSERT INTO su_permissions (code, description) VALUES
+    ('questions:moderate', 'Review flags, read the moderation log and run badge backfills'),
+    ('questions:close', 'Vote to close and reopen questions without the reputation threshold'),
+    ('posts:edit', 'Edit posts of other users without the reputation threshold'),
+    ('roles:manage', 'Manage roles and assign them to users'),
+    ('tags:manage', 'Edit tag descriptions and add synonyms without the reputation threshold'),
+    ('queries:execute', 'Run the queries of the query catalog')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO su_roles (id, code, name, description) VALUES
+    ('880e8400-e29b-41d4-a716-446655440001', 'admin', 'Administrator', 'Every permission'),
+    ('880e8400-e29b-41d4-a716-446655440002', 'moderator', 'Moderator', 'Moderates questions and posts')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO su_role_permissions (role_id, permission_code) VALUES
+    ('880e8400-e29b-41d4-a716-446655440001', 'questions:moderate'),
+    ('880e8400-e29b-41d4-a716-446655440001', 'questions:close'),
+    ('880e8400-e29b-41d4-a716-446655440001', 'posts:edit'),
+    ('880e8400-e29b-41d4-a716-446655440001', 'roles:manage'),
+    ('880e8400-e29b-41d4-a716-446655440001', 'tags:manage'),
+    ('880e8400-e29b-41d4-a716-446655440001', 'queries:execute'),
+    ('880e8400-e29b-41d4-a716-446655440002', 'questions:moderate'),
+    ('880e8400-e29b-41d4-a716-446655440002', 'questions:close'),
+    ('880e8400-e29b-41d4-a716-446655440002', 'posts:edit'),
+    ('880e8400-e29b-41d4-a716-446655440002', 'tags:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO su_user_roles (user_id, role_id) VALUES
+    ('550e8400-e29b-41d4-a716-446655440001', '880e8400-e29b-41d4-a716-446655440001')
+ON CONFLICT DO NOTHING;
+
 -- Insert sample questions
 INSERT INTO su_questions (id, title, description, status, user_id, username, created_at) VALUES
     ('660e8400-e29b-41d4-a716-446655440001', 'How do I center a div in CSS?', 